@@ -0,0 +1,99 @@
+// add file in v.1.0.6 (move from middleware/request_binder.go)
+// default_value.go is file that declare function setting value declared in default tag into field not set in request
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// tag declaring default value of field, ex) Count int `form:"count" default:"10"`
+const defaultTagKey = "default"
+
+// set value in default tag into fields of request entity (pointer of struct) having zero value
+// it is used in middleware.RequestValidator & handler calling other handler with request entity (ex. dashboard)
+func SetDefaultValues(req interface{}) error {
+	return setDefaultValues(reflect.ValueOf(req))
+}
+
+// set value in default tag into fields having zero value, fields of embedded or nested struct are also set
+func setDefaultValues(v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		sf, field := v.Type().Field(i), v.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if sf.Type.Kind() == reflect.Struct || (sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct) {
+			if err := setDefaultValues(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		def, ok := sf.Tag.Lookup(defaultTagKey)
+		if !ok || !field.IsZero() {
+			continue
+		}
+		if err := setFieldWithString(field, def); err != nil {
+			return errors.New(fmt.Sprintf("invalid default value of field %s, value: %s, err: %v", sf.Name, def, err))
+		}
+	}
+	return nil
+}
+
+// set string value into field according to kind of field
+func setFieldWithString(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return errors.New(fmt.Sprintf("default tag is not supported in field of kind %s", field.Kind()))
+	}
+	return nil
+}
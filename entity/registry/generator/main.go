@@ -0,0 +1,377 @@
+// add file in v.1.0.6
+// main.go is file that generate request entity registry (entity/registry/request_entity_gen.go) from entity source files
+// and files binding handlers with request entity (<package>/request_handler_gen.go) in handler packages
+// bound handlers are found in routing, so handler not bound in generated code is unable to be routed (change in v.1.0.6)
+// run with go generate in entity/registry, ex) go generate ./entity/registry
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// packages in which handler of request entity is declared as method (handler.(*_default).XXX, router.(*customRouter).Batch)
+var handlerPackages = []string{"handler", "router"}
+
+const (
+	registryFile = "entity/registry/request_entity_gen.go"
+	handlerFile  = "request_handler_gen.go"
+	generatedBy  = "// Code generated by entity/registry/generator with go generate; DO NOT EDIT.\n\n"
+)
+
+// entityStruct is struct declared in request entity file
+type entityStruct struct {
+	name     string
+	file     string
+	hasURI   bool
+	hasForm  bool
+	hasJSON  bool
+	hasHead  bool     // true if some field has header tag
+	hasFile  bool     // true if some field is *multipart.FileHeader, which is bound only in multipart body
+	embedded []string // names of entity structs embedded without tag
+	refs     []string // names of entity structs used as type of field
+}
+
+// handlerMethod is exported method declared in handler package
+type handlerMethod struct {
+	name     string // method name, ex) CreateNewStudent
+	pkg      string // package directory, ex) handler
+	recv     string // receiver type expression, ex) *_default
+	recvName string // receiver name, ex) h
+	isGin    bool   // true if method is gin handler, func(c *gin.Context)
+}
+
+func main() {
+	root := flag.String("root", ".", "path of gateway module root")
+	flag.Parse()
+
+	generate(*root)
+}
+
+// generate request entity registry & bound handlers in gateway module of root
+func generate(root string) {
+	structs := parseEntityStructs(filepath.Join(root, "entity"))
+	methods := map[string][]handlerMethod{}
+	for _, pkg := range handlerPackages {
+		parseHandlerMethods(filepath.Join(root, pkg), pkg, methods)
+	}
+
+	referenced := map[string]bool{}
+	for _, s := range structs {
+		for _, ref := range append(append([]string{}, s.refs...), s.embedded...) {
+			referenced[ref] = true
+		}
+	}
+
+	var names []string
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	registry := bytes.Buffer{}
+	registry.WriteString(generatedBy)
+	registry.WriteString("package registry\n\nimport (\n\t\"gateway/entity\"\n\t\"reflect\"\n)\n\n")
+	registry.WriteString("// request entities bound in handler, referenced in bound handlers generated in handler package\n")
+	registry.WriteString("var (\n")
+	bound, nested := bytes.Buffer{}, bytes.Buffer{}
+	asserts := map[string][]string{}
+	entityOf := map[string]string{} // name of entity bound in handler, key is handler name
+	for _, name := range names {
+		s := structs[name]
+		if !strings.HasSuffix(name, "Request") {
+			log.Fatalf("regex of all struct in request entity files must be \"^.*Request$\", struct name: %s, file: %s", name, s.file)
+		}
+
+		handlerName := strings.TrimSuffix(name, "Request")
+		candidates := methods[handlerName]
+		if len(candidates) > 1 {
+			log.Fatalf("handler of request entity is declared in several packages, struct name: %s, handler: %v", name, candidates)
+		}
+		switch {
+		case len(candidates) == 1:
+			method := candidates[0]
+			bindings := s.bindings(structs)
+			registry.WriteString(fmt.Sprintf("\t%s = Entity{Name: %q, Type: reflect.TypeOf(entity.%s{}), Bindings: %s}\n",
+				name, name, name, strings.Join(bindings, " | ")))
+			bound.WriteString(fmt.Sprintf("\t%s,\n", name))
+			if method.isGin {
+				entityOf[handlerName] = name
+			} else {
+				// method returning handler (ex. router.(*customRouter).Batch) binds returned handler by itself
+				asserts[method.pkg] = append(asserts[method.pkg], fmt.Sprintf("\t_ = (%s).%s\n", method.recv, handlerName))
+			}
+		case referenced[name]:
+			nested.WriteString(fmt.Sprintf("\t{Name: %q, Type: reflect.TypeOf(entity.%s{})},\n", name, name))
+		default:
+			log.Fatalf("request entity must be bound in handler named %s or used in other request entity, struct name: %s, file: %s",
+				handlerName, name, s.file)
+		}
+	}
+	registry.WriteString(")\n\n")
+	registry.WriteString("// request entities bound in handler\n")
+	registry.WriteString("var requestEntities = []Entity{\n")
+	registry.Write(bound.Bytes())
+	registry.WriteString("}\n\n")
+	registry.WriteString("// request entities not bound in handler directly, used as field of other request entity\n")
+	registry.WriteString("var nestedRequestEntities = []Entity{\n")
+	registry.Write(nested.Bytes())
+	registry.WriteString("}\n")
+	writeSource(filepath.Join(root, registryFile), registry.Bytes())
+
+	for _, pkg := range handlerPackages {
+		writeSource(filepath.Join(root, pkg, handlerFile), handlerSource(pkg, methods, entityOf, asserts[pkg]))
+	}
+}
+
+// return source of file declaring bound handlers (gin handler methods with request entity) of each receiver in package
+// & asserting that methods returning handler bound with request entity exist
+func handlerSource(pkg string, methods map[string][]handlerMethod, entityOf map[string]string, asserts []string) []byte {
+	var names []string
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var recvs []string
+	bindings := map[string][]string{} // bound handlers of receiver, key is receiver type expression
+	recvNames := map[string]string{}
+	for _, name := range names {
+		for _, method := range methods[name] {
+			if method.pkg != pkg || !method.isGin {
+				continue
+			}
+			if _, ok := bindings[method.recv]; !ok {
+				recvs = append(recvs, method.recv)
+				recvNames[method.recv] = method.recvName
+			}
+			binding := fmt.Sprintf("\t\t{Name: %q, Handler: %s.%s", name, recvNames[method.recv], name)
+			if entityName, ok := entityOf[name]; ok {
+				binding += fmt.Sprintf(", Entity: &entityregistry.%s", entityName)
+			}
+			bindings[method.recv] = append(bindings[method.recv], binding+"},\n")
+		}
+	}
+
+	src := bytes.Buffer{}
+	src.WriteString(generatedBy)
+	src.WriteString(fmt.Sprintf("package %s\n\n", pkg))
+	if len(recvs) != 0 {
+		src.WriteString("import entityregistry \"gateway/entity/registry\"\n\n")
+	}
+	for _, recv := range recvs {
+		src.WriteString("// return gin handlers declared as method with request entity bound in each handler, which are bound in router\n")
+		src.WriteString("// build fails if handler or request entity is renamed or removed\n")
+		src.WriteString(fmt.Sprintf("func (%s %s) BoundHandlers() []entityregistry.BoundHandler {\n", recvNames[recv], recv))
+		src.WriteString("\treturn []entityregistry.BoundHandler{\n")
+		for _, binding := range bindings[recv] {
+			src.WriteString(binding)
+		}
+		src.WriteString("\t}\n}\n")
+	}
+	if len(asserts) != 0 {
+		src.WriteString("// methods returning handler bound with request entity, build fails if method is renamed or removed\n")
+		src.WriteString("var (\n")
+		for _, assert := range asserts {
+			src.WriteString(assert)
+		}
+		src.WriteString(")\n")
+	}
+	return src.Bytes()
+}
+
+// return binding constants of entity, fields of embedded entity are included
+func (s *entityStruct) bindings(structs map[string]*entityStruct) (bindings []string) {
+	hasURI, hasForm, hasJSON, hasFile, hasHead := s.hasURI, s.hasForm, s.hasJSON, s.hasFile, s.hasHead
+	for _, name := range s.embedded {
+		if e, ok := structs[name]; ok {
+			hasURI, hasForm, hasJSON, hasFile = hasURI || e.hasURI, hasForm || e.hasForm, hasJSON || e.hasJSON, hasFile || e.hasFile
+			hasHead = hasHead || e.hasHead
+		}
+	}
+
+	if hasURI {
+		bindings = append(bindings, "BindURI")
+	}
+	if hasHead {
+		bindings = append(bindings, "BindHeader")
+	}
+	if hasForm && !hasFile {
+		bindings = append(bindings, "BindQuery")
+	}
+	if hasJSON || hasFile || !(hasURI || hasForm || hasHead) {
+		bindings = append(bindings, "BindBody")
+	}
+	return
+}
+
+// parse struct declared in request entity files (request*.go except request_event*.go) in entity directory
+func parseEntityStructs(dir string) map[string]*entityStruct {
+	paths, err := filepath.Glob(filepath.Join(dir, "request*.go"))
+	if err != nil {
+		log.Fatalf("unable to find request entity files, dir: %s, err: %v", dir, err)
+	}
+
+	structs := map[string]*entityStruct{}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if strings.HasPrefix(base, "request_event") || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			log.Fatalf("unable to parse file, file: %s, err: %v", path, err)
+		}
+
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				s := &entityStruct{name: typeSpec.Name.Name, file: base}
+				s.inspectFields(structType.Fields.List)
+				structs[s.name] = s
+			}
+		}
+	}
+	return structs
+}
+
+// set binding tags, file field, embedded & referenced struct of entity from fields
+func (s *entityStruct) inspectFields(fields []*ast.Field) {
+	for _, field := range fields {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(unquoted)
+		}
+		_, uri := tag.Lookup("uri")
+		_, form := tag.Lookup("form")
+		_, json := tag.Lookup("json")
+		_, header := tag.Lookup("header")
+		s.hasURI, s.hasForm, s.hasJSON, s.hasHead = s.hasURI || uri, s.hasForm || form, s.hasJSON || json, s.hasHead || header
+
+		if star, ok := field.Type.(*ast.StarExpr); ok {
+			if sel, ok := star.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "FileHeader" {
+				s.hasFile = true
+			}
+		}
+
+		if len(field.Names) == 0 && field.Tag == nil {
+			if ident, ok := field.Type.(*ast.Ident); ok {
+				s.embedded = append(s.embedded, ident.Name)
+			}
+			continue
+		}
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.Ident:
+				s.refs = append(s.refs, x.Name)
+			case *ast.StructType:
+				// anonymous struct in field is bound as part of this entity
+				s.inspectFields(x.Fields.List)
+				return false
+			case *ast.SelectorExpr:
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// parse exported methods declared in package directory
+func parseHandlerMethods(dir, pkg string, methods map[string][]handlerMethod) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		log.Fatalf("unable to find handler files, dir: %s, err: %v", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == handlerFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			log.Fatalf("unable to parse file, file: %s, err: %v", path, err)
+		}
+
+		for _, decl := range f.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || !funcDecl.Name.IsExported() {
+				continue
+			}
+			recv := funcDecl.Recv.List[0].Type
+			recvExpr := ""
+			if star, ok := recv.(*ast.StarExpr); ok {
+				if ident, ok := star.X.(*ast.Ident); ok {
+					recvExpr = "*" + ident.Name
+				}
+			} else if ident, ok := recv.(*ast.Ident); ok {
+				recvExpr = ident.Name
+			}
+			if recvExpr == "" {
+				continue
+			}
+			method := handlerMethod{name: funcDecl.Name.Name, pkg: pkg, recv: recvExpr, isGin: isGinHandler(funcDecl.Type)}
+			if names := funcDecl.Recv.List[0].Names; len(names) != 0 {
+				method.recvName = names[0].Name
+			}
+			if method.isGin && method.recvName == "" {
+				log.Fatalf("receiver of gin handler must be named, file: %s, handler: %s", path, method.name)
+			}
+			methods[method.name] = append(methods[method.name], method)
+		}
+	}
+}
+
+// return true if function type is gin handler, func(c *gin.Context) without result
+func isGinHandler(fn *ast.FuncType) bool {
+	if fn.Results != nil && len(fn.Results.List) != 0 {
+		return false
+	}
+	if params := fn.Params.List; len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := fn.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "gin" && sel.Sel.Name == "Context"
+}
+
+// format source & write it in path
+func writeSource(path string, src []byte) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		log.Fatalf("unable to format generated source, file: %s, err: %v\n%s", path, err, src)
+	}
+	if err = ioutil.WriteFile(path, formatted, 0644); err != nil {
+		log.Fatalf("unable to write generated source, file: %s, err: %v", path, err)
+	}
+}
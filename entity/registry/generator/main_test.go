@@ -0,0 +1,129 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// source files of gateway module in which code is generated in test, key is path from module root
+var testSources = map[string]string{
+	"entity/request_thing.go": `package entity
+
+type CreateThingRequest struct {
+	ThingUUID string ` + "`uri:\"thing_uuid\"`" + `
+	Name      string ` + "`json:\"name\"`" + `
+}
+
+type GetThingsRequest struct {
+	Start int ` + "`form:\"start\" default:\"0\"`" + `
+	Count int ` + "`form:\"count\" default:\"10\"`" + `
+}
+
+type BatchRequest struct {
+	Requests []BatchSubRequest ` + "`json:\"requests\"`" + `
+}
+
+type BatchSubRequest struct {
+	Path string ` + "`json:\"path\"`" + `
+}
+`,
+	"handler/default.go": `package handler
+
+import "github.com/gin-gonic/gin"
+
+type _default struct{}
+
+func (h *_default) CreateThing(c *gin.Context) {}
+func (h *_default) GetThings(c *gin.Context)   {}
+func (h *_default) GetThingWithUUID(c *gin.Context) {}
+func (h *_default) Publisher() func() error { return nil }
+func (h *_default) unexported(c *gin.Context) {}
+`,
+	"router/custom_batch.go": `package router
+
+import "github.com/gin-gonic/gin"
+
+type customRouter struct{}
+
+func (r *customRouter) Batch(concurrency int) gin.HandlerFunc { return nil }
+`,
+}
+
+// generate code in temporary gateway module having testSources, return function reading generated source in module
+// temporary directory should be removed by caller with returned root
+func generateInTestModule(t *testing.T) (root string, source func(path string) string) {
+	root, err := ioutil.TempDir("", "generator")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory, err: %v", err)
+	}
+
+	for path, src := range testSources {
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0755); err != nil {
+			t.Fatalf("unable to create directory, err: %v", err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(root, path), []byte(src), 0644); err != nil {
+			t.Fatalf("unable to write source file, err: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "entity", "registry"), 0755); err != nil {
+		t.Fatalf("unable to create directory, err: %v", err)
+	}
+	generate(root)
+
+	return root, func(path string) string {
+		b, err := ioutil.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			t.Fatalf("generated file is not found, file: %s, err: %v", path, err)
+		}
+		return string(b)
+	}
+}
+
+// check if request entities are generated with bindings read from struct tags (add in v.1.0.6)
+func TestGenerateRegistry(t *testing.T) {
+	root, source := generateInTestModule(t)
+	defer os.RemoveAll(root)
+	registry := source(registryFile)
+
+	for _, expected := range []string{
+		`CreateThingRequest = Entity{Name: "CreateThingRequest", Type: reflect.TypeOf(entity.CreateThingRequest{}), Bindings: BindURI | BindBody}`,
+		`GetThingsRequest   = Entity{Name: "GetThingsRequest", Type: reflect.TypeOf(entity.GetThingsRequest{}), Bindings: BindQuery}`,
+		`BatchRequest       = Entity{Name: "BatchRequest", Type: reflect.TypeOf(entity.BatchRequest{}), Bindings: BindBody}`,
+		`{Name: "BatchSubRequest", Type: reflect.TypeOf(entity.BatchSubRequest{})}`,
+	} {
+		if !strings.Contains(registry, expected) {
+			t.Errorf("request entity is not generated in registry, expected: %s\n%s", expected, registry)
+		}
+	}
+}
+
+// check if gin handlers are bound with request entity of same name & handlers without request entity are bound without entity
+func TestGenerateBoundHandlers(t *testing.T) {
+	root, source := generateInTestModule(t)
+	defer os.RemoveAll(root)
+	handler, router := source(filepath.Join("handler", handlerFile)), source(filepath.Join("router", handlerFile))
+
+	for _, expected := range []string{
+		`func (h *_default) BoundHandlers() []entityregistry.BoundHandler {`,
+		`{Name: "CreateThing", Handler: h.CreateThing, Entity: &entityregistry.CreateThingRequest},`,
+		`{Name: "GetThings", Handler: h.GetThings, Entity: &entityregistry.GetThingsRequest},`,
+		`{Name: "GetThingWithUUID", Handler: h.GetThingWithUUID},`,
+	} {
+		if !strings.Contains(handler, expected) {
+			t.Errorf("handler is not bound in generated code, expected: %s\n%s", expected, handler)
+		}
+	}
+	for _, unexpected := range []string{"Publisher", "unexported"} {
+		if strings.Contains(handler, unexpected) {
+			t.Errorf("method which is not exported gin handler must not be bound, method: %s\n%s", unexpected, handler)
+		}
+	}
+
+	// method returning handler binds returned handler by itself, so only existence of method is asserted
+	if !strings.Contains(router, "_ = (*customRouter).Batch") || strings.Contains(router, "BoundHandlers") {
+		t.Errorf("existence of method returning handler must be asserted without bound handlers\n%s", router)
+	}
+}
@@ -0,0 +1,55 @@
+// add file in v.1.0.6 (replace request_instance.go parsing entity files in runtime)
+// request_entity.go is file that declare request entity & handler bound with it in code generated by go generate
+// entities are declared in request_entity_gen.go & bound handlers are declared in <package>/request_handler_gen.go,
+// so binary doesn't need source files of entity & renamed or removed handler and entity fail build (change in v.1.0.6)
+
+package registry
+
+import (
+	"github.com/gin-gonic/gin"
+	"reflect"
+)
+
+//go:generate go run ./generator -root ../..
+
+// Binding is set of request sources which request entity is bound from in middleware.RequestValidator
+type Binding uint8
+
+const (
+	BindURI   Binding = 1 << iota // bind path parameters to fields with uri tag
+	BindQuery                     // bind query string to fields with form tag (entity having form tag without file field)
+	BindBody                      // bind body according to content type (multipart, json, form)
+	BindHeader                    // bind request headers to fields with header tag
+)
+
+// Entity is request entity bound in handler, Name is struct name in entity package (ex. CreateNewStudentRequest)
+type Entity struct {
+	Name     string
+	Type     reflect.Type
+	Bindings Binding
+}
+
+// return new instance (pointer of struct) of request entity
+func (e Entity) New() interface{} {
+	return reflect.New(e.Type).Interface()
+}
+
+// return true if request entity is bound from all sources in b
+func (e Entity) BindsFrom(b Binding) bool {
+	return e.Bindings&b == b
+}
+
+// BoundHandler is handler bound with request entity, declared in generated code of handler package
+// Entity is nil if handler doesn't bind request entity, then handler reads only path parameters or nothing
+type BoundHandler struct {
+	Name    string // name of handler, ex) CreateNewStudent
+	Handler gin.HandlerFunc
+	Entity  *Entity
+}
+
+// return all request entities including nested entities not bound in handler directly (ex. BatchSubRequest)
+func All() (entities []Entity) {
+	entities = append(entities, requestEntities...)
+	entities = append(entities, nestedRequestEntities...)
+	return
+}
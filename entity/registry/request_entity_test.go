@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"gateway/tool/redact"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// value set in sensitive fields, which must not be found in redacted json
+const sensitiveValue = "sensitive-value-in-test"
+
+// check if all sensitive fields in request entities have redact tag & are masked in log (add in v.1.0.6)
+func TestSensitiveFieldsRedacted(t *testing.T) {
+	for _, e := range All() {
+		if fields := redact.UntaggedSensitiveFields(e.New()); len(fields) != 0 {
+			t.Errorf("sensitive fields in request entity must have log:\"redact\" tag, entity name: %s, fields: %v", e.Name, fields)
+			continue
+		}
+
+		req := e.New()
+		v := reflect.ValueOf(req).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Type().Field(i); f.Tag.Get("log") == "redact" && v.Field(i).Kind() == reflect.String {
+				v.Field(i).SetString(sensitiveValue)
+			}
+		}
+
+		b, err := redact.Marshal(req)
+		if err != nil {
+			t.Errorf("unable to marshal redacted request entity, entity name: %s, err: %v", e.Name, err)
+			continue
+		}
+		if strings.Contains(string(b), sensitiveValue) {
+			t.Errorf("sensitive field in request entity is not masked, entity name: %s, json: %s", e.Name, string(b))
+		}
+	}
+}
+
+// check if untagged sensitive field in nested struct, pointer, slice is found like CreateNewParentRequest.Children (add in v.1.0.6)
+func TestNestedSensitiveFieldsFound(t *testing.T) {
+	type child struct {
+		Name        string `json:"name"`
+		PhoneNumber string `json:"phone_number"`
+	}
+	req := struct {
+		ParentPW string `json:"parent_pw" log:"redact"`
+		Children []struct {
+			Inform child   `json:"inform"`
+			Parent *child  `json:"parent"`
+			Tagged []child `json:"tagged" log:"redact"`
+		} `json:"children"`
+	}{}
+
+	expected := []string{"Children.Inform.PhoneNumber", "Children.Parent.PhoneNumber"}
+	if fields := redact.UntaggedSensitiveFields(&req); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("untagged sensitive fields in nested struct must be found, expected: %v, fields: %v", expected, fields)
+	}
+}
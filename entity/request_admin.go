@@ -0,0 +1,24 @@
+// add file in v.1.0.6
+// request_admin.go is file that definition request entity about gateway administration API
+
+package entity
+
+type ChangeLogLevelRequest struct {
+	Group string `json:"group"` // change all logger groups if empty
+	Level string `json:"level" validate:"required,values=trace&debug&info&warning&error&fatal&panic"`
+}
+
+// request entity of DELETE /v1/admin/lockouts
+type ClearLockoutRequest struct {
+	Scope string `form:"scope" validate:"required"`
+	Key   string `form:"key" validate:"required"` // ex. account:jinhong07191, ip:127.0.0.1
+}
+
+// request entity of GET /v1/admin/audit-records
+type GetAuditRecordsRequest struct {
+	ActorUUID string `form:"actor_uuid"`
+	Target    string `form:"target"`
+	From      int64  `form:"from"` // unix time
+	To        int64  `form:"to"`   // unix time
+	Limit     int    `form:"limit" validate:"min=0,max=1000"`
+}
@@ -25,15 +25,12 @@ func (from CreateAnnouncementRequest) GenerateGRPCRequest() (to *announcementpro
 
 // request entity of GET /v1/announcements/types/{type}
 type GetAnnouncementsRequest struct {
-	Start int32  `form:"start"`
-	Count int32  `form:"count"`
+	Start  int32  `form:"start"`
+	Count  int32  `form:"count" default:"10"`
+	Cursor string `form:"cursor"` // signed cursor of next page, start & count are ignored if set (add in v.1.0.6)
 }
 
 func (from GetAnnouncementsRequest) GenerateGRPCRequest() (to *announcementproto.GetAnnouncementsRequest) {
-	if from.Count == 0 {
-		from.Count = 10
-	}
-
 	to = new(announcementproto.GetAnnouncementsRequest)
 	to.Start = from.Start
 	to.Count = from.Count
@@ -60,14 +57,10 @@ func (from UpdateAnnouncementRequest) GenerateGRPCRequest() (to *announcementpro
 // request entity of GET /v1/announcements/types/{type}/query/{query}
 type SearchAnnouncementsRequest struct {
 	Start int32  `form:"start"`
-	Count int32  `form:"count"`
+	Count int32  `form:"count" default:"10"`
 }
 
 func (from SearchAnnouncementsRequest) GenerateGRPCRequest() (to *announcementproto.SearchAnnouncementsRequest) {
-	if from.Count == 0 {
-		from.Count = 10
-	}
-
 	to = new(announcementproto.SearchAnnouncementsRequest)
 	to.Start = from.Start
 	to.Count = from.Count
@@ -76,15 +69,12 @@ func (from SearchAnnouncementsRequest) GenerateGRPCRequest() (to *announcementpr
 
 // request entity of GET /v1/announcements/writer-uuid/{writer_uuid}
 type GetMyAnnouncementsRequest struct {
-	Start int32  `form:"start"`
-	Count int32  `form:"count"`
+	Start  int32  `form:"start"`
+	Count  int32  `form:"count" default:"10"`
+	Cursor string `form:"cursor"` // signed cursor of next page, start & count are ignored if set (add in v.1.0.6)
 }
 
 func (from GetMyAnnouncementsRequest) GenerateGRPCRequest() (to *announcementproto.GetMyAnnouncementsRequest) {
-	if from.Count == 0 {
-		from.Count = 10
-	}
-
 	to = new(announcementproto.GetMyAnnouncementsRequest)
 	to.Start = from.Start
 	to.Count = from.Count
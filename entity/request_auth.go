@@ -8,13 +8,13 @@ import (
 // request entity of POST /v1/students
 type CreateNewStudentRequest struct {
 	StudentID     string `form:"student_id" validate:"required,min=4,max=16"`
-	StudentPW     string `form:"student_pw" validate:"required,min=4,max=16"`
+	StudentPW     string `form:"student_pw" validate:"required,min=4,max=16" log:"redact"`
 	ParentUUID    string `form:"parent_uuid" validate:"uuid=parent"`
 	Grade         int    `form:"grade" validate:"required,int_range=1~3"`
 	Group         int    `form:"group" validate:"required,int_range=1~4"`
 	StudentNumber int    `form:"student_number" validate:"required,int_range=1~21"`
 	Name          string `form:"name" validate:"required,korean,min=2,max=4"`
-	PhoneNumber   string `form:"phone_number" validate:"required,phone_number,len=11"`
+	PhoneNumber   string `form:"phone_number" validate:"required,phone_number,len=11" log:"redact"`
 	Profile       *multipart.FileHeader `form:"profile" validate:"required"`
 }
 
@@ -40,11 +40,11 @@ func (from CreateNewStudentRequest) GenerateGRPCRequest() (to *authproto.CreateN
 // request entity of POST /v1/teachers
 type CreateNewTeacherRequest struct {
 	TeacherID   string `json:"teacher_id" validate:"required,min=4,max=16"`
-	TeacherPW   string `json:"teacher_pw" validate:"required,min=4,max=16"`
+	TeacherPW   string `json:"teacher_pw" validate:"required,min=4,max=16" log:"redact"`
 	Grade       int    `json:"grade" validate:"int_range=0~3"`
 	Group       int    `json:"group" validate:"int_range=0~4"`
 	Name        string `json:"name" validate:"required,korean,min=2,max=4"`
-	PhoneNumber string `json:"phone_number" validate:"required,phone_number,len=11"`
+	PhoneNumber string `json:"phone_number" validate:"required,phone_number,len=11" log:"redact"`
 }
 
 func (from CreateNewTeacherRequest) GenerateGRPCRequest() (to *authproto.CreateNewTeacherRequest) {
@@ -61,9 +61,9 @@ func (from CreateNewTeacherRequest) GenerateGRPCRequest() (to *authproto.CreateN
 // request entity of POST /v1/parents
 type CreateNewParentRequest struct {
 	ParentID    string `json:"parent_id" validate:"required,min=4,max=16"`
-	ParentPW    string `json:"parent_pw" validate:"required,min=4,max=16"`
+	ParentPW    string `json:"parent_pw" validate:"required,min=4,max=16" log:"redact"`
 	Name        string `json:"name" validate:"required,korean,min=2,max=4"`
-	PhoneNumber string `json:"phone_number" validate:"phone_number"`
+	PhoneNumber string `json:"phone_number" validate:"phone_number" log:"redact"`
 	Children        []struct {
 		Grade         int    `json:"grade" validate:"required,int_range=1~3"`
 		Group         int    `json:"group" validate:"int_range=1~4"`
@@ -93,7 +93,7 @@ func (from CreateNewParentRequest) GenerateGRPCRequest() (to *authproto.CreateNe
 // request entity of POST v1/login/admin
 type LoginAdminAuthRequest struct {
 	AdminID    string `json:"admin_id" validate:"required"`
-	AdminPW    string `json:"admin_pw" validate:"required"`
+	AdminPW    string `json:"admin_pw" validate:"required" log:"redact"`
 }
 
 func (from LoginAdminAuthRequest) GenerateGRPCRequest() (to *authproto.LoginAdminAuthRequest) {
@@ -106,7 +106,7 @@ func (from LoginAdminAuthRequest) GenerateGRPCRequest() (to *authproto.LoginAdmi
 // request entity of POST v1/login/student
 type LoginStudentAuthRequest struct {
 	StudentID    string `json:"student_id" validate:"required"`
-	StudentPW    string `json:"student_pw" validate:"required"`
+	StudentPW    string `json:"student_pw" validate:"required" log:"redact"`
 }
 
 func (from LoginStudentAuthRequest) GenerateGRPCRequest() (to *authproto.LoginStudentAuthRequest) {
@@ -118,8 +118,8 @@ func (from LoginStudentAuthRequest) GenerateGRPCRequest() (to *authproto.LoginSt
 
 // request entity for PUT v1/students/uuid/:student_uuid/password
 type ChangeStudentPWRequest struct {
-	CurrentPW   string `json:"current_pw" validate:"required"`
-	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16"`
+	CurrentPW   string `json:"current_pw" validate:"required" log:"redact"`
+	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16" log:"redact"`
 }
 
 func (from ChangeStudentPWRequest) GenerateGRPCRequest() (to *authproto.ChangeStudentPWRequest) {
@@ -135,7 +135,7 @@ type GetStudentUUIDsWithInformRequest struct {
 	Group         int    `form:"group"`
 	StudentNumber int    `form:"student_number"`
 	Name          string `form:"name"`
-	PhoneNumber   string `form:"phone_number"`
+	PhoneNumber   string `form:"phone_number" log:"redact"`
 	ProfileURI    string `form:"profile_uri"`
 }
 
@@ -164,7 +164,7 @@ func (from GetStudentInformsWithUUIDsRequest) GenerateGRPCRequest() (to *authpro
 // request entity of POST v1/login/teacher
 type LoginTeacherAuthRequest struct {
 	TeacherID string `json:"teacher_id" validate:"required"`
-	TeacherPW string `json:"teacher_pw" validate:"required"`
+	TeacherPW string `json:"teacher_pw" validate:"required" log:"redact"`
 }
 
 func (from LoginTeacherAuthRequest) GenerateGRPCRequest() (to *authproto.LoginTeacherAuthRequest) {
@@ -177,7 +177,7 @@ func (from LoginTeacherAuthRequest) GenerateGRPCRequest() (to *authproto.LoginTe
 // request entity of POST v1/login/teacher/with-pick
 type LoginTeacherAuthWithPICKRequest struct {
 	TeacherID string `json:"teacher_id" validate:"required"`
-	TeacherPW string `json:"teacher_pw" validate:"required"`
+	TeacherPW string `json:"teacher_pw" validate:"required" log:"redact"`
 }
 
 func (from LoginTeacherAuthWithPICKRequest) GenerateGRPCRequest() (to *authproto.LoginTeacherAuthWithPICKRequest) {
@@ -189,8 +189,8 @@ func (from LoginTeacherAuthWithPICKRequest) GenerateGRPCRequest() (to *authproto
 
 // request entity for PUT v1/teachers/uuid/:teacher_uuid/password
 type ChangeTeacherPWRequest struct {
-	CurrentPW   string `json:"current_pw" validate:"required"`
-	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16"`
+	CurrentPW   string `json:"current_pw" validate:"required" log:"redact"`
+	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16" log:"redact"`
 }
 
 func (from ChangeTeacherPWRequest) GenerateGRPCRequest() (to *authproto.ChangeTeacherPWRequest) {
@@ -205,7 +205,7 @@ type GetTeacherUUIDsWithInformRequest struct {
 	Grade         int    `form:"grade"`
 	Group         int    `form:"group"`
 	Name          string `form:"name"`
-	PhoneNumber   string `form:"phone_number"`
+	PhoneNumber   string `form:"phone_number" log:"redact"`
 }
 
 func (from GetTeacherUUIDsWithInformRequest) GenerateGRPCRequest() (to *authproto.GetTeacherUUIDsWithInformRequest) {
@@ -219,7 +219,7 @@ func (from GetTeacherUUIDsWithInformRequest) GenerateGRPCRequest() (to *authprot
 
 // request entity for PATCH v1/teachers/uuid/:teacher_uuid
 type ChangeTeacherInformRequest struct {
-	PhoneNumber string `json:"phone_number" validate:"phone_number"`
+	PhoneNumber string `json:"phone_number" validate:"phone_number" log:"redact"`
 }
 
 func (from ChangeTeacherInformRequest) GenerateGRPCRequest() (to *authproto.ChangeTeacherInformRequest) {
@@ -231,7 +231,7 @@ func (from ChangeTeacherInformRequest) GenerateGRPCRequest() (to *authproto.Chan
 // request entity of POST v1/login/parent
 type LoginParentAuthRequest struct {
 	ParentID string `json:"parent_id" validate:"required"`
-	ParentPW string `json:"parent_pw" validate:"required"`
+	ParentPW string `json:"parent_pw" validate:"required" log:"redact"`
 }
 
 func (from LoginParentAuthRequest) GenerateGRPCRequest() (to *authproto.LoginParentAuthRequest) {
@@ -243,8 +243,8 @@ func (from LoginParentAuthRequest) GenerateGRPCRequest() (to *authproto.LoginPar
 
 // request entity for PUT v1/parents/uuid/:parent_uuid/password
 type ChangeParentPWRequest struct {
-	CurrentPW   string `json:"current_pw" validate:"required"`
-	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16"`
+	CurrentPW   string `json:"current_pw" validate:"required" log:"redact"`
+	RevisionPW  string `json:"revision_pw" validate:"required,min=4,max=16" log:"redact"`
 }
 
 func (from ChangeParentPWRequest) GenerateGRPCRequest() (to *authproto.ChangeParentPWRequest) {
@@ -257,7 +257,7 @@ func (from ChangeParentPWRequest) GenerateGRPCRequest() (to *authproto.ChangePar
 // request entity for GET /v1/parent-uuids
 type GetParentUUIDsWithInformRequest struct {
 	Name          string `form:"name"`
-	PhoneNumber   string `form:"phone_number"`
+	PhoneNumber   string `form:"phone_number" log:"redact"`
 }
 
 func (from GetParentUUIDsWithInformRequest) GenerateGRPCRequest() (to *authproto.GetParentUUIDsWithInformRequest) {
@@ -282,7 +282,7 @@ func (from SendJoinSMSToUnsignedStudentsRequest) GenerateGRPCRequest() (to *auth
 }
 
 type GetUnsignedStudentWithAuthCodeRequest struct {
-	AuthCode int `uri:"auth_code" validate:"required"`
+	AuthCode int `uri:"auth_code" validate:"required" log:"redact"`
 }
 
 func (from GetUnsignedStudentWithAuthCodeRequest) GenerateGRPCRequest() (to *authproto.GetUnsignedStudentWithAuthCodeRequest) {
@@ -292,9 +292,9 @@ func (from GetUnsignedStudentWithAuthCodeRequest) GenerateGRPCRequest() (to *aut
 }
 
 type CreateNewStudentWithAuthCodeRequest struct {
-	AuthCode  int    `json:"auth_code" validate:"required"`
+	AuthCode  int    `json:"auth_code" validate:"required" log:"redact"`
 	StudentID string `json:"student_id" validate:"required,min=4,max=16"`
-	StudentPW string `json:"student_pw" validate:"required,min=4,max=16"`
+	StudentPW string `json:"student_pw" validate:"required,min=4,max=16" log:"redact"`
 }
 
 func (from CreateNewStudentWithAuthCodeRequest) GenerateGRPCRequest() (to *authproto.CreateNewStudentWithAuthCodeRequest) {
@@ -0,0 +1,18 @@
+// add file in v.1.0.6
+// request_batch.go is file that definition request entity about batch API running several API in one request
+
+package entity
+
+import "encoding/json"
+
+// request entity of POST /v1/batch
+type BatchRequest struct {
+	Requests []BatchSubRequest `json:"requests" validate:"required,min=1,max=20,dive"`
+}
+
+// sub request in batch request, dispatched to this gateway with header (Authorization, etc ...) of batch request
+type BatchSubRequest struct {
+	Method string          `json:"method" validate:"required,values=GET&POST&PUT&PATCH&DELETE"`
+	Path   string          `json:"path" validate:"required,startswith=/,max=2048"` // path with query string, ex. /v1/clubs/sorted-by/update-time?start=0
+	Body   json.RawMessage `json:"body"`                                           // json body of sub request
+}
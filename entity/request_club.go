@@ -37,10 +37,11 @@ func (from CreateNewClubRequest) GenerateGRPCRequest() (to *clubproto.CreateNewC
 
 // request entity of GET /v1/clubs/paging
 type GetClubsSortByUpdateTimeRequest struct {
-	Start int    `form:"start"`
-	Count int    `form:"count"`
-	Field string `form:"field"`
-	Name  string `form:"name"`
+	Start  int    `form:"start"`
+	Count  int    `form:"count" default:"10"`
+	Field  string `form:"field"`
+	Name   string `form:"name"`
+	Cursor string `form:"cursor"` // signed cursor of next page, start & count are ignored if set (add in v.1.0.6)
 }
 
 func (from GetClubsSortByUpdateTimeRequest) GenerateGRPCRequest() (to *clubproto.GetClubsSortByUpdateTimeRequest) {
@@ -55,7 +56,7 @@ func (from GetClubsSortByUpdateTimeRequest) GenerateGRPCRequest() (to *clubproto
 // request entity of GET /v1/recruitments/paging
 type GetRecruitmentsSortByCreateTimeRequest struct {
 	Start int    `form:"start"`
-	Count int    `form:"count"`
+	Count int    `form:"count" default:"10"`
 	Field string `form:"field"`
 	Name  string `form:"name"`
 }
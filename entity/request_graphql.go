@@ -0,0 +1,22 @@
+// add file in v.1.0.6
+// request_graphql.go is file that definition request entity about GraphQL API
+
+package entity
+
+// request entity of POST /graphql
+type GraphQLRequest struct {
+	Query         string                   `json:"query" validate:"max=10000"` // can be empty if persisted query hash is set in extensions
+	OperationName string                   `json:"operationName"`
+	Variables     map[string]interface{}   `json:"variables"`
+	Extensions    GraphQLExtensionsRequest `json:"extensions"`
+}
+
+// extensions of GraphQL request, same format with Apollo persisted query
+type GraphQLExtensionsRequest struct {
+	PersistedQuery *GraphQLPersistedQueryRequest `json:"persistedQuery"`
+}
+
+type GraphQLPersistedQueryRequest struct {
+	Version    int    `json:"version"`
+	SHA256Hash string `json:"sha256Hash" validate:"required,len=64,hexadecimal"`
+}
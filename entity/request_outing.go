@@ -25,15 +25,12 @@ func (from CreateOutingRequest) GenerateGRPCRequest() (to *outingproto.CreateOut
 
 // request entity of GET /v1/students/uuid/:student_uuid/outings
 type GetStudentOutingsRequest struct {
-	Start int32  `form:"start"`
-	Count int32  `form:"count"`
+	Start  int32  `form:"start"`
+	Count  int32  `form:"count" default:"10"`
+	Cursor string `form:"cursor"` // signed cursor of next page, start & count are ignored if set (add in v.1.0.6)
 }
 
 func (from GetStudentOutingsRequest) GenerateGRPCRequest() (to *outingproto.GetStudentOutingsRequest) {
-	if from.Count == 0 {
-		from.Count = 10
-	}
-
 	to = new(outingproto.GetStudentOutingsRequest)
 	to.Start = from.Start
 	to.Count = from.Count
@@ -65,6 +62,12 @@ func (from GetOutingWithFilterRequest) GenerateGRPCRequest() (to *outingproto.Ge
 	return
 }
 
+// request entity of GET /v1/outings/with-filter/dashboard (add in v.1.0.6)
+// filter & pagination (start, count) are same with GET /v1/outings/with-filter
+type GetOutingDashboardWithFilterRequest struct {
+	GetOutingWithFilterRequest
+}
+
 // request entity of PATCH /v1/outings/uuid/:outing_uuid
 type ModifyOutingRequest struct {
 	EndTime int64 `json:"end_time" validate:"required,int_len=10"`
@@ -75,3 +78,8 @@ func (from ModifyOutingRequest) GenerateGRPCRequest() (to *outingproto.ModifyOut
 	to.EndTime = from.EndTime
 	return
 }
+
+// request entity of POST /v1/outings/uuid/:outing_uuid/parent-action-links (add in v.1.0.6)
+type IssueParentActionLinksRequest struct {
+	ConfirmCode string `json:"confirm_code" validate:"required" log:"redact"`
+}
@@ -37,7 +37,7 @@ type GetTimeTableRequest struct {
 	Year  int32 `uri:"year" validate:"required,int_range=0~9999"`
 	Month int32 `uri:"month" validate:"required,int_range=1~12"`
 	Day   int32 `uri:"day" validate:"required,int_range=1~31"`
-	Count int32 `form:"count"`
+	Count int32 `form:"count" default:"1"`
 }
 
 func (from GetTimeTableRequest) GenerateGRPCRequest() (to *scheduleproto.GetTimeTablesRequest) {
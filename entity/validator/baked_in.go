@@ -102,3 +102,14 @@ func isCorrectIntLen(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+// struct level rule checking if at least one exported field is set, registered to struct type (add in v.1.0.6)
+func hasAtLeastOneField(sl validator.StructLevel) {
+	current := sl.Current()
+	for i := 0; i < current.NumField(); i++ {
+		if current.Type().Field(i).PkgPath == "" && !current.Field(i).IsZero() {
+			return
+		}
+	}
+	sl.ReportError(current.Interface(), current.Type().Name(), current.Type().Name(), "at_least_one", "")
+}
@@ -0,0 +1,156 @@
+// add file in v.1.0.6
+// field_error.go is file that declare function converting validation errors into field errors responded to client
+// with message of each rule (including custom rules in baked_in.go) in language negotiated with Accept-Language
+
+package validator
+
+import (
+	"fmt"
+	"gateway/tool/i18n"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+// binding tags read in order to get field name in request, field name in golang is used if none of them exists
+var bindingTagKeys = []string{"json", "form", "uri", "header"}
+
+// FieldError is error of field violating rule in validate tag, responded in errors of 400 response
+type FieldError struct {
+	Field    string `json:"field"`     // field path in golang struct, ex) Children[0].Name
+	JSONName string `json:"json_name"` // field path in request, ex) children[0].name
+	Rule     string `json:"rule"`      // rule in validate tag, ex) int_range
+	Param    string `json:"param"`     // param of rule, ex) 1~3
+	Message  string `json:"message"`
+}
+
+// messages of rules (%s in message is replaced with param), rules whose message depends on kind of field (min, max, len) are in lengthMessages
+var messages = map[string]i18n.Messages{
+	"required":     {i18n.Korean: "필수 입력 항목입니다", i18n.English: "is required"},
+	"uuid":         {i18n.Korean: "%[1]s UUID 형식이어야 합니다 (예: %[1]s-123412341234)", i18n.English: "must be %[1]s UUID (ex. %[1]s-123412341234)"},
+	"int_range":    {i18n.Korean: "%s부터 %s 사이의 정수여야 합니다", i18n.English: "must be integer between %s and %s"},
+	"korean":       {i18n.Korean: "한글만 입력할 수 있습니다", i18n.English: "must contain only korean characters"},
+	"phone_number": {i18n.Korean: "010으로 시작하는 11자리 전화번호여야 합니다", i18n.English: "must be 11 digits phone number starting with 010"},
+	"time":         {i18n.Korean: "YYYY-MM-DD 형식이어야 합니다", i18n.English: "must be in YYYY-MM-DD format"},
+	"values":       {i18n.Korean: "다음 값 중 하나여야 합니다: %s", i18n.English: "must be one of %s"},
+	"oneof":        {i18n.Korean: "다음 값 중 하나여야 합니다: %s", i18n.English: "must be one of %s"},
+	"int_len":      {i18n.Korean: "%s자리 정수여야 합니다", i18n.English: "must be %s digits integer"},
+	"startswith":   {i18n.Korean: "'%s'(으)로 시작해야 합니다", i18n.English: "must start with '%s'"},
+	"hexadecimal":  {i18n.Korean: "16진수 문자열이어야 합니다", i18n.English: "must be hexadecimal string"},
+	"email":        {i18n.Korean: "이메일 형식이어야 합니다", i18n.English: "must be email address"},
+	"at_least_one": {i18n.Korean: "하나 이상의 항목을 입력해야 합니다", i18n.English: "at least one field must be set"},
+}
+
+// messages of rules limiting length of string, number of items or value of number
+var lengthMessages = map[string]map[string]i18n.Messages{
+	"min": {
+		"string": {i18n.Korean: "%s자 이상이어야 합니다", i18n.English: "must be at least %s characters"},
+		"items":  {i18n.Korean: "%s개 이상이어야 합니다", i18n.English: "must have at least %s items"},
+		"number": {i18n.Korean: "%s 이상이어야 합니다", i18n.English: "must be %s or greater"},
+	},
+	"max": {
+		"string": {i18n.Korean: "%s자 이하여야 합니다", i18n.English: "must be at most %s characters"},
+		"items":  {i18n.Korean: "%s개 이하여야 합니다", i18n.English: "must have at most %s items"},
+		"number": {i18n.Korean: "%s 이하여야 합니다", i18n.English: "must be %s or less"},
+	},
+	"len": {
+		"string": {i18n.Korean: "%s자여야 합니다", i18n.English: "must be exactly %s characters"},
+		"items":  {i18n.Korean: "%s개여야 합니다", i18n.English: "must have exactly %s items"},
+		"number": {i18n.Korean: "%s여야 합니다", i18n.English: "must be %s"},
+	},
+}
+
+// message of rule not declared above
+var unknownRuleMessage = i18n.Messages{i18n.Korean: "'%s' 규칙을 만족하지 않습니다", i18n.English: "failed on '%s' rule"}
+
+// return name of field in binding tag, used as field name in namespace of validation error
+func bindingTagName(sf reflect.StructField) string {
+	for _, key := range bindingTagKeys {
+		if name := strings.Split(sf.Tag.Get(key), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return ""
+}
+
+// convert validation errors returned from validator to field errors with message in lang
+// false is returned if err is not validation errors (ex. InvalidValidationError)
+func FieldErrors(err error, lang i18n.Language) ([]FieldError, bool) {
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil, false
+	}
+
+	fieldErrs := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		field, jsonName := trimNamespace(fe.StructNamespace()), trimNamespace(fe.Namespace())
+		if fe.Tag() == "at_least_one" {
+			// struct level error is reported with name of struct, so path of struct is used as field path
+			field, jsonName = parentPath(field), parentPath(jsonName)
+		}
+		fieldErrs = append(fieldErrs, FieldError{
+			Field:    field,
+			JSONName: jsonName,
+			Rule:     fe.Tag(),
+			Param:    fe.Param(),
+			Message:  Message(fe, lang),
+		})
+	}
+	return fieldErrs, true
+}
+
+// return message of rule violated in field error in lang
+func Message(fe validator.FieldError, lang i18n.Language) string {
+	msg, ok := messages[fe.Tag()]
+	if kindMessages, isLength := lengthMessages[fe.Tag()]; isLength {
+		msg, ok = kindMessages[kindOfLength(fe.Kind())], true
+	}
+	if !ok {
+		return fmt.Sprintf(unknownRuleMessage.In(lang), fe.Tag())
+	}
+
+	args := []interface{}{fe.Param()}
+	switch fe.Tag() {
+	case "int_range":
+		args = []interface{}{}
+		for _, bound := range strings.SplitN(fe.Param()+"~", "~", 3)[:2] {
+			args = append(args, bound)
+		}
+	case "values":
+		args[0] = strings.Join(strings.Split(fe.Param(), "&"), ", ")
+	case "oneof":
+		args[0] = strings.Join(strings.Fields(fe.Param()), ", ")
+	}
+
+	if !strings.Contains(msg.In(lang), "%") {
+		return msg.In(lang)
+	}
+	return fmt.Sprintf(msg.In(lang), args...)
+}
+
+// return kind of length limited by min, max & len rule
+func kindOfLength(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	}
+	return "number"
+}
+
+// remove name of request entity at front of namespace, ex) CreateNewParentRequest.Children[0].Name -> Children[0].Name
+func trimNamespace(namespace string) string {
+	if sep := strings.Index(namespace, "."); sep != -1 {
+		return namespace[sep+1:]
+	}
+	return namespace
+}
+
+// return path of parent in field path, ex) Children[0].Name -> Children[0], Name -> ""
+func parentPath(path string) string {
+	if sep := strings.LastIndex(path, "."); sep != -1 {
+		return path[:sep]
+	}
+	return ""
+}
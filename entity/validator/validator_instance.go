@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"gateway/entity"
 	"github.com/go-playground/validator/v10"
 	"log"
 )
@@ -17,6 +18,13 @@ func init() {
 	if err := entityValidator.RegisterValidation("time", isTime); err != nil { log.Fatal(err) } // 문자열 전용
 	if err := entityValidator.RegisterValidation("values", isValidValue); err != nil { log.Fatal(err) } // 문자열 전용
 	if err := entityValidator.RegisterValidation("int_len", isCorrectIntLen); err != nil { log.Fatal(err) } // 정수 전용
+
+	// use field name in binding tag as namespace of validation error, which is responded as json_name (add in v.1.0.6)
+	entityValidator.RegisterTagNameFunc(bindingTagName)
+
+	// request entities which must have at least one field set (add in v.1.0.6)
+	entityValidator.RegisterStructValidation(hasAtLeastOneField, entity.GetStudentUUIDsWithInformRequest{},
+		entity.GetTeacherUUIDsWithInformRequest{}, entity.GetParentUUIDsWithInformRequest{})
 }
 
 func New() *validator.Validate {
@@ -4,7 +4,7 @@ go 1.13
 
 require (
 	github.com/360EntSecGroup-Skylar/excelize/v2 v2.3.2
-	github.com/HdrHistogram/hdrhistogram-go v1.0.0 // indirect
+	github.com/andybalholm/brotli v1.0.1
 	github.com/aws/aws-sdk-go v1.23.0
 	github.com/bshuster-repo/logrus-logstash-hook v1.0.0
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
@@ -15,14 +15,20 @@ require (
 	github.com/go-playground/validator/v10 v10.4.1
 	github.com/go-redis/redis/v8 v8.4.11
 	github.com/golang/protobuf v1.4.3
-	github.com/google/uuid v1.1.1
+	github.com/google/uuid v1.1.2
 	github.com/hashicorp/consul/api v1.1.0
 	github.com/mervick/aes-everywhere/go/aes256 v0.0.0-20201120204945-cd607c782ed1
 	github.com/micro/go-micro/v2 v2.9.1
 	github.com/opentracing/opentracing-go v1.2.0
 	github.com/sirupsen/logrus v1.7.0
 	github.com/stretchr/testify v1.6.1
-	github.com/uber/jaeger-client-go v2.25.0+incompatible
-	github.com/uber/jaeger-lib v2.4.0+incompatible // indirect
+	github.com/swaggo/files v1.0.1
+	github.com/ugorji/go/codec v1.1.7
+	go.opentelemetry.io/otel v0.16.0
+	go.opentelemetry.io/otel/bridge/opentracing v0.16.0
+	go.opentelemetry.io/otel/exporters/otlp v0.16.0
+	go.opentelemetry.io/otel/sdk v0.16.0
+	golang.org/x/sync v0.0.0-20220722155255-886fb9371eb4
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 )
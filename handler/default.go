@@ -8,6 +8,8 @@ import (
 	clubproto "gateway/proto/golang/club"
 	outingproto "gateway/proto/golang/outing"
 	scheduleproto "gateway/proto/golang/schedule"
+	"gateway/tool/audit"
+	"gateway/tool/graphql"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/eapache/go-resiliency/breaker"
 	"github.com/go-playground/validator/v10"
@@ -23,6 +25,9 @@ import (
 type serviceName string
 type consulIndex string
 
+// DefaultHandler is exported name of default handler type, used in signature of function receiving handler returned from Default (add in v.1.0.6)
+type DefaultHandler = _default
+
 type _default struct {
 	authService authproto.AuthServiceClient
 	clubService clubproto.ClubServiceClient
@@ -49,6 +54,22 @@ type _default struct {
 
 	// redis client for cashing responses of services (Add in v.1.0.3)
 	redisClient *redis.Client
+
+	// store of audit record about privileged operations (Add in v.1.0.6)
+	auditStore audit.Store
+
+	// schema & persisted query allowlist of GraphQL facade (Add in v.1.0.6)
+	graphQLSchema           *graphql.Schema
+	graphQLPersistedQueries graphql.PersistedQueries
+
+	// hub sending student events to event stream connections of this gateway (Add in v.1.0.6)
+	eventHub *studentEventHub
+
+	// min size of response cached in redis to be precompressed with gzip (Add in v.1.0.6)
+	compressionMinSize int
+
+	// configuration read from environment variable in ConfigFromEnv (Add in v.1.0.6)
+	config Config
 }
 
 type BreakerConfig struct {
@@ -59,6 +80,7 @@ type BreakerConfig struct {
 
 func Default(setters ...FieldSetter) (h *_default) {
 	h = new(_default)
+	h.config = DefaultConfig() // add in v.1.0.6
 	for _, setter := range setters {
 		setter(h)
 	}
@@ -73,6 +95,8 @@ func Default(setters ...FieldSetter) (h *_default) {
 	h.breakers = map[string]*breaker.Breaker{}
 	h.client = &http.Client{}
 	h.consulIndexFilter = map[serviceName]map[consulIndex][]entity.PublishConsulChangeEventRequest{}
+	h.graphQLSchema = h.newGraphQLSchema() // add in v.1.0.6
+	h.eventHub = newStudentEventHub()      // add in v.1.0.6
 
 	return
 }
@@ -150,3 +174,27 @@ func RedisClient(r *redis.Client) FieldSetter {
 		h.redisClient = r
 	}
 }
+
+func Configuration(cfg Config) FieldSetter {
+	return func(h *_default) {
+		h.config = cfg
+	}
+}
+
+func AuditStore(store audit.Store) FieldSetter {
+	return func(h *_default) {
+		h.auditStore = store
+	}
+}
+
+func GraphQLPersistedQueries(queries graphql.PersistedQueries) FieldSetter {
+	return func(h *_default) {
+		h.graphQLPersistedQueries = queries
+	}
+}
+
+func CompressionMinSize(size int) FieldSetter {
+	return func(h *_default) {
+		h.compressionMinSize = size
+	}
+}
@@ -0,0 +1,219 @@
+// add file in v.1.0.6
+// default_admin.go is file that declare handler of API administrating gateway itself (log level, etc ...)
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"gateway/entity"
+	"gateway/tool/attempt"
+	"gateway/tool/audit"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	customlogrus "gateway/tool/logrus"
+	"gateway/tool/redact"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+var adminUUIDRegex = regexp.MustCompile("^admin-\\d{12}$")
+
+func (h *_default) GetLogLevels(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only admin can get log level of gateway"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg}).Warn()
+		return
+	}
+
+	msg := "succeed to get log level of all logger groups"
+	sendResp := gin.H{"status": http.StatusOK, "code": 0, "message": msg, "levels": customlogrus.Levels()}
+	envelope.JSON(c, http.StatusOK, sendResp)
+	respBytes, _ := redact.Marshal(sendResp)
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": msg, "response": string(respBytes)}).Info()
+	return
+}
+
+func (h *_default) ChangeLogLevel(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.ChangeLogLevelRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only admin can change log level of gateway"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg, "request": string(reqBytes)}).Warn()
+		return
+	}
+
+	if err := customlogrus.SetLevel(receivedReq.Group, receivedReq.Level); err != nil {
+		msg := fmt.Sprintf("unable to change log level, err: %v", err)
+		envelope.JSON(c, http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusNotFound, "code": 0, "message": msg, "request": string(reqBytes)}).Info()
+		return
+	}
+
+	// log with warn level to leave history of changing log level even if level become higher than info
+	msg := "succeed to change log level"
+	sendResp := gin.H{"status": http.StatusOK, "code": 0, "message": msg, "levels": customlogrus.Levels()}
+	envelope.JSON(c, http.StatusOK, sendResp)
+	respBytes, _ := redact.Marshal(sendResp)
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": msg, "response": string(respBytes),
+		"request": string(reqBytes)}).Warn()
+	return
+}
+
+// add in v.1.0.6
+func (h *_default) GetLockouts(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only admin can get lockouts of gateway"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg}).Warn()
+		return
+	}
+
+	lockouts, err := attempt.NewTracker(h.redisClient).Lockouts(context.Background())
+	if err != nil {
+		msg := fmt.Sprintf("unable to get lockouts from redis, err: %v", err)
+		envelope.JSON(c, http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": msg}).Error()
+		return
+	}
+
+	lockoutsForResp := make([]map[string]interface{}, len(lockouts))
+	for index, lockout := range lockouts {
+		lockoutsForResp[index] = map[string]interface{}{
+			"scope":          lockout.Scope,
+			"key":            lockout.Key,
+			"remain_seconds": int64(lockout.Remain.Seconds()),
+		}
+	}
+
+	msg := "succeed to get lockouts"
+	sendResp := gin.H{"status": http.StatusOK, "code": 0, "message": msg, "lockouts": lockoutsForResp}
+	envelope.JSON(c, http.StatusOK, sendResp)
+	respBytes, _ := redact.Marshal(sendResp)
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": msg, "response": string(respBytes)}).Info()
+	return
+}
+
+// add in v.1.0.6
+func (h *_default) ClearLockout(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.ClearLockoutRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only admin can clear lockout of gateway"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg, "request": string(reqBytes)}).Warn()
+		return
+	}
+
+	if err := attempt.NewTracker(h.redisClient).Clear(context.Background(), receivedReq.Scope, receivedReq.Key); err != nil {
+		msg := fmt.Sprintf("unable to clear lockout, err: %v", err)
+		envelope.JSON(c, http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusNotFound, "code": 0, "message": msg, "request": string(reqBytes)}).Info()
+		return
+	}
+
+	msg := "succeed to clear lockout"
+	sendResp := gin.H{"status": http.StatusOK, "code": 0, "message": msg}
+	envelope.JSON(c, http.StatusOK, sendResp)
+	respBytes, _ := redact.Marshal(sendResp)
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": msg, "response": string(respBytes),
+		"request": string(reqBytes)}).Warn()
+	return
+}
+
+// add in v.1.0.6
+func (h *_default) GetAuditRecords(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.GetAuditRecordsRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only admin can get audit records of gateway"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg, "request": string(reqBytes)}).Warn()
+		return
+	}
+
+	filter := audit.Filter{ActorUUID: receivedReq.ActorUUID, Target: receivedReq.Target, Limit: receivedReq.Limit}
+	if receivedReq.From != 0 {
+		filter.From = time.Unix(receivedReq.From, 0)
+	}
+	if receivedReq.To != 0 {
+		filter.To = time.Unix(receivedReq.To, 0)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 100
+	}
+
+	records, err := h.auditStore.Query(context.Background(), filter)
+	if err != nil {
+		msg := fmt.Sprintf("unable to query audit records, err: %v", err)
+		envelope.JSON(c, http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": msg, "request": string(reqBytes)}).Error()
+		return
+	}
+
+	// records are not written in log because they are already in audit store
+	msg := "succeed to get audit records"
+	envelope.JSON(c, http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": msg, "records": records})
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": msg, "request": string(reqBytes),
+		"record_count": len(records)}).Info()
+	return
+}
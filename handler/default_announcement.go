@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	announcementproto "gateway/proto/golang/announcement"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/eapache/go-resiliency/breaker"
@@ -17,7 +18,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -41,12 +41,12 @@ func (h *_default) CreateAnnouncement(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateAnnouncementRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -61,14 +61,14 @@ func (h *_default) CreateAnnouncement(c *gin.Context) {
 	var rpcResp *announcementproto.DefaultAnnouncementResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("CreateAnnouncement", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.CreateAnnouncement(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -86,7 +86,7 @@ func (h *_default) CreateAnnouncement(c *gin.Context) {
 			msg = fmt.Sprintf("CreateAnnouncement returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -101,7 +101,7 @@ func (h *_default) CreateAnnouncement(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateAnnouncement returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -111,14 +111,14 @@ func (h *_default) CreateAnnouncement(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := "succeed to create new announcement"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "announcement_uuid": rpcResp.AnnouncementId}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -144,12 +144,12 @@ func (h *_default) GetAnnouncements(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetAnnouncementsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -164,15 +164,15 @@ func (h *_default) GetAnnouncements(c *gin.Context) {
 	var rpcResp *announcementproto.GetAnnouncementsResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("GetAnnouncements", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.Type = c.Param("type")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.GetAnnouncements(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -190,7 +190,7 @@ func (h *_default) GetAnnouncements(c *gin.Context) {
 			msg = fmt.Sprintf("GetAnnouncements returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -205,7 +205,7 @@ func (h *_default) GetAnnouncements(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetAnnouncements returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -227,14 +227,14 @@ func (h *_default) GetAnnouncements(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "announcements": announcements, "size": rpcResp.Size}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -257,10 +257,10 @@ func (h *_default) GetAnnouncementDetail(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -275,15 +275,15 @@ func (h *_default) GetAnnouncementDetail(c *gin.Context) {
 	var rpcResp *announcementproto.GetAnnouncementDetailResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("GetAnnouncementDetail", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := new(announcementproto.GetAnnouncementDetailRequest)
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.AnnouncementId = c.Param("announcement_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.GetAnnouncementDetail(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -301,7 +301,7 @@ func (h *_default) GetAnnouncementDetail(c *gin.Context) {
 			msg = fmt.Sprintf("GetAnnouncementDetail returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -316,7 +316,7 @@ func (h *_default) GetAnnouncementDetail(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetAnnouncementDetail returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -329,14 +329,14 @@ func (h *_default) GetAnnouncementDetail(c *gin.Context) {
 			"content": rpcResp.Content, "writer_name": rpcResp.WriterName, "target_grade": rpcResp.TargetGrade, "target_group": rpcResp.TargetGroup,
 			"type": rpcResp.AnnouncementType, "next_title": rpcResp.NextTitle, "next_announcement_uuid": rpcResp.NextAnnouncementId,
 			"previous_title": rpcResp.PreviousTitle, "previous_announcement_uuid": rpcResp.PreviousAnnouncementId}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -362,12 +362,12 @@ func (h *_default) UpdateAnnouncement(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.UpdateAnnouncementRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -382,15 +382,15 @@ func (h *_default) UpdateAnnouncement(c *gin.Context) {
 	var rpcResp *announcementproto.DefaultAnnouncementResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("UpdateAnnouncement", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.AnnouncementId = c.Param("announcement_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.UpdateAnnouncement(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -408,7 +408,7 @@ func (h *_default) UpdateAnnouncement(c *gin.Context) {
 			msg = fmt.Sprintf("UpdateAnnouncement returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -423,7 +423,7 @@ func (h *_default) UpdateAnnouncement(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("UpdateAnnouncement returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -433,14 +433,14 @@ func (h *_default) UpdateAnnouncement(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to update announcement"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "announcement_uuid": rpcResp.AnnouncementId}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -463,10 +463,10 @@ func (h *_default) DeleteAnnouncement(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -481,15 +481,15 @@ func (h *_default) DeleteAnnouncement(c *gin.Context) {
 	var rpcResp *announcementproto.DefaultAnnouncementResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("DeleteAnnouncement", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := new(announcementproto.DeleteAnnouncementRequest)
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.AnnouncementId = c.Param("announcement_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.DeleteAnnouncement(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -507,7 +507,7 @@ func (h *_default) DeleteAnnouncement(c *gin.Context) {
 			msg = fmt.Sprintf("DeleteAnnouncement returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -522,7 +522,7 @@ func (h *_default) DeleteAnnouncement(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("DeleteAnnouncement returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -532,14 +532,14 @@ func (h *_default) DeleteAnnouncement(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to get announcement detail inform with uuid"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "announcement_uuid": rpcResp.AnnouncementId}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -562,10 +562,10 @@ func (h *_default) CheckAnnouncement(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -580,14 +580,14 @@ func (h *_default) CheckAnnouncement(c *gin.Context) {
 	var rpcResp *announcementproto.CheckAnnouncementResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("CheckAnnouncement", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := new(announcementproto.CheckAnnouncementRequest)
 		rpcReq.Uuid = c.Param("student_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.CheckAnnouncement(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -605,7 +605,7 @@ func (h *_default) CheckAnnouncement(c *gin.Context) {
 			msg = fmt.Sprintf("CheckAnnouncement returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -620,7 +620,7 @@ func (h *_default) CheckAnnouncement(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CheckAnnouncement returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -630,14 +630,14 @@ func (h *_default) CheckAnnouncement(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to get if non-check announcement is exist"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "club": rpcResp.Club, "school": rpcResp.School}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -663,12 +663,12 @@ func (h *_default) SearchAnnouncements(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.SearchAnnouncementsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -683,16 +683,16 @@ func (h *_default) SearchAnnouncements(c *gin.Context) {
 	var rpcResp *announcementproto.GetAnnouncementsResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("SearchAnnouncements", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.Type = c.Param("type")
 		rpcReq.Query = c.Param("search_query")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.SearchAnnouncements(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -710,7 +710,7 @@ func (h *_default) SearchAnnouncements(c *gin.Context) {
 			msg = fmt.Sprintf("SearchAnnouncements returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -725,7 +725,7 @@ func (h *_default) SearchAnnouncements(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("SearchAnnouncements returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -747,14 +747,14 @@ func (h *_default) SearchAnnouncements(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "size": rpcResp.Size, "announcements": announcements}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -780,12 +780,12 @@ func (h *_default) GetMyAnnouncements(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetMyAnnouncementsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AnnouncementServiceName)
+	selectedNode, err := h.selectNode(c, topic.AnnouncementServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -800,14 +800,14 @@ func (h *_default) GetMyAnnouncements(c *gin.Context) {
 	var rpcResp *announcementproto.GetAnnouncementsResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		announcementSrvSpan := h.tracer.StartSpan("GetMyAnnouncements", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", announcementSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, announcementSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = c.Param("writer_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.announcementService.GetMyAnnouncements(ctxForReq, rpcReq, callOpts...)
-		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		announcementSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		announcementSrvSpan.Finish()
 		return
 	})
@@ -825,7 +825,7 @@ func (h *_default) GetMyAnnouncements(c *gin.Context) {
 			msg = fmt.Sprintf("GetMyAnnouncements returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -840,7 +840,7 @@ func (h *_default) GetMyAnnouncements(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetMyAnnouncements returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -862,14 +862,14 @@ func (h *_default) GetMyAnnouncements(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "size": rpcResp.Size, "message": msg, "announcements": announcements}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	authproto "gateway/proto/golang/auth"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/dgrijalva/jwt-go"
@@ -18,7 +19,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -42,12 +42,12 @@ func (h *_default) CreateNewStudent(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateNewStudentRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -62,14 +62,14 @@ func (h *_default) CreateNewStudent(c *gin.Context) {
 	var rpcResp *authproto.CreateNewStudentResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("CreateNewStudent", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := []client.CallOption{client.WithDialTimeout(time.Second * 2), client.WithRequestTimeout(time.Second * 6), client.WithAddress(selectedNode.Address)}
 		rpcResp, rpcErr = h.authService.CreateNewStudent(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -87,7 +87,7 @@ func (h *_default) CreateNewStudent(c *gin.Context) {
 			msg = fmt.Sprintf("CreateNewStudent returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -102,7 +102,7 @@ func (h *_default) CreateNewStudent(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateNewStudent returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -112,14 +112,14 @@ func (h *_default) CreateNewStudent(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := "succeed to create new student"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "student_uuid": rpcResp.CreatedStudentUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -145,12 +145,12 @@ func (h *_default) CreateNewParent(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateNewParentRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -165,14 +165,14 @@ func (h *_default) CreateNewParent(c *gin.Context) {
 	var rpcResp *authproto.CreateNewParentResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("CreateNewParent", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.CreateNewParent(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -190,7 +190,7 @@ func (h *_default) CreateNewParent(c *gin.Context) {
 			msg = fmt.Sprintf("CreateNewParent returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -205,7 +205,7 @@ func (h *_default) CreateNewParent(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateNewParent returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -215,14 +215,14 @@ func (h *_default) CreateNewParent(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := "succeed to create new parent"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "parent_uuid": rpcResp.CreatedParentUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -243,12 +243,12 @@ func (h *_default) LoginAdminAuth(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.LoginAdminAuthRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -263,13 +263,13 @@ func (h *_default) LoginAdminAuth(c *gin.Context) {
 	var rpcResp *authproto.LoginAdminAuthResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("LoginAdminAuth", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.LoginAdminAuth(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -287,7 +287,7 @@ func (h *_default) LoginAdminAuth(c *gin.Context) {
 			msg = fmt.Sprintf("LoginAdminAuth returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -302,7 +302,7 @@ func (h *_default) LoginAdminAuth(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("LoginAdminAuth returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -319,15 +319,15 @@ func (h *_default) LoginAdminAuth(c *gin.Context) {
 			},
 		}, jwt.SigningMethodHS512)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "access_token": jwtToken, "admin_uuid": rpcResp.LoggedInAdminUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "login_uuid": rpcResp.LoggedInAdminUUID,
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -353,13 +353,13 @@ func (h *_default) SendJoinSMSToUnsignedStudents(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.SendJoinSMSToUnsignedStudentsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
 	// get service node
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -367,14 +367,14 @@ func (h *_default) SendJoinSMSToUnsignedStudents(c *gin.Context) {
 
 	// send gRPC request
 	authSrvSpan := h.tracer.StartSpan("SendJoinSMSToUnsignedStudents", opentracing.ChildOf(topSpan.Context()))
-	ctxForReq := context.Background()
+	ctxForReq := rpcContext(c)
 	ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-	ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+	ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 	rpcReq := receivedReq.GenerateGRPCRequest()
 	rpcReq.UUID = uuidClaims.UUID
 	callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 	rpcResp, rpcErr := h.authService.SendJoinSMSToUnsignedStudents(ctxForReq, rpcReq, callOpts...)
-	authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+	authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 	authSrvSpan.Finish()
 
 	switch rpcErr := err.(type) {
@@ -390,13 +390,13 @@ func (h *_default) SendJoinSMSToUnsignedStudents(c *gin.Context) {
 			msg = fmt.Sprintf("SendJoinSMSToUnsignedStudents returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
 		status, _code := http.StatusInternalServerError, 0
 		msg := fmt.Sprintf("SendJoinSMSToUnsignedStudents returns unexpected type of error, err: %s", rpcErr.Error())
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -405,14 +405,14 @@ func (h *_default) SendJoinSMSToUnsignedStudents(c *gin.Context) {
 	case http.StatusOK:
 		status, _code := http.StatusOK, 0
 		sendResp := gin.H{"status": status, "code": _code, "message": rpcResp.Message, "no_send_count": rpcResp.SendCount, "send_count": rpcResp.SendCount}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": rpcResp.Message, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 }
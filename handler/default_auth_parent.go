@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	authproto "gateway/proto/golang/auth"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/dgrijalva/jwt-go"
@@ -18,7 +19,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -37,12 +37,12 @@ func (h *_default) LoginParentAuth(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.LoginParentAuthRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -57,13 +57,13 @@ func (h *_default) LoginParentAuth(c *gin.Context) {
 	var rpcResp *authproto.LoginParentAuthResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("LoginParentAuth", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.LoginParentAuth(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -81,7 +81,7 @@ func (h *_default) LoginParentAuth(c *gin.Context) {
 			msg = fmt.Sprintf("LoginParentAuth returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -96,7 +96,7 @@ func (h *_default) LoginParentAuth(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("LoginParentAuth returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -113,15 +113,15 @@ func (h *_default) LoginParentAuth(c *gin.Context) {
 			},
 		}, jwt.SigningMethodHS512)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "access_token": jwtToken, "parent_uuid": rpcResp.LoggedInParentUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "login_uuid": rpcResp.LoggedInParentUUID,
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -147,12 +147,12 @@ func (h *_default) ChangeParentPW(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.ChangeParentPWRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -167,15 +167,15 @@ func (h *_default) ChangeParentPW(c *gin.Context) {
 	var rpcResp *authproto.ChangeParentPWResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("ChangeParentPW", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.ParentUUID = c.Param("parent_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.ChangeParentPW(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -193,7 +193,7 @@ func (h *_default) ChangeParentPW(c *gin.Context) {
 			msg = fmt.Sprintf("ChangeParentPW returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -208,7 +208,7 @@ func (h *_default) ChangeParentPW(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("ChangeParentPW returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -218,14 +218,14 @@ func (h *_default) ChangeParentPW(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := fmt.Sprintf("succeed to change auth password of %s", uuidClaims.UUID)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -248,10 +248,10 @@ func (h *_default) GetParentInformWithUUID(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -266,15 +266,15 @@ func (h *_default) GetParentInformWithUUID(c *gin.Context) {
 	var rpcResp *authproto.GetParentInformWithUUIDResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetParentInformWithUUID", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := new(authproto.GetParentInformWithUUIDRequest)
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.ParentUUID = c.Param("parent_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetParentInformWithUUID(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -292,7 +292,7 @@ func (h *_default) GetParentInformWithUUID(c *gin.Context) {
 			msg = fmt.Sprintf("GetParentInformWithUUID returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -307,7 +307,7 @@ func (h *_default) GetParentInformWithUUID(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetParentInformWithUUID returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -320,14 +320,14 @@ func (h *_default) GetParentInformWithUUID(c *gin.Context) {
 			"status": status, "code": _code, "message": msg,
 			"name": rpcResp.Name, "phone_number": rpcResp.PhoneNumber,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
 	}
 
@@ -353,12 +353,12 @@ func (h *_default) GetParentUUIDsWithInform(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetParentUUIDsWithInformRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -373,14 +373,14 @@ func (h *_default) GetParentUUIDsWithInform(c *gin.Context) {
 	var rpcResp *authproto.GetParentUUIDsWithInformResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetParentUUIDsWithInform", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetParentUUIDsWithInform(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -398,7 +398,7 @@ func (h *_default) GetParentUUIDsWithInform(c *gin.Context) {
 			msg = fmt.Sprintf("GetParentUUIDsWithInform returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -413,7 +413,7 @@ func (h *_default) GetParentUUIDsWithInform(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetParentUUIDsWithInform returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -423,14 +423,14 @@ func (h *_default) GetParentUUIDsWithInform(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to get parent uuid list with inform"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "parent_uuids": rpcResp.ParentUUIDs}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -453,10 +453,10 @@ func (h *_default) GetChildrenInformsWithUUID(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -471,15 +471,15 @@ func (h *_default) GetChildrenInformsWithUUID(c *gin.Context) {
 	var rpcResp *authproto.GetChildrenInformsWithUUIDResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetChildrenInformsWithUUID", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := new(authproto.GetChildrenInformsWithUUIDRequest)
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.ParentUUID = c.Param("parent_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetChildrenInformsWithUUID(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -497,7 +497,7 @@ func (h *_default) GetChildrenInformsWithUUID(c *gin.Context) {
 			msg = fmt.Sprintf("GetChildrenInformsWithUUID returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -512,7 +512,7 @@ func (h *_default) GetChildrenInformsWithUUID(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetChildrenInformsWithUUID returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -534,14 +534,14 @@ func (h *_default) GetChildrenInformsWithUUID(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "children": children}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
 	}
 
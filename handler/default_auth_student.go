@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	authproto "gateway/proto/golang/auth"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/dgrijalva/jwt-go"
@@ -18,7 +19,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -37,12 +37,12 @@ func (h *_default) LoginStudentAuth(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.LoginStudentAuthRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -57,13 +57,13 @@ func (h *_default) LoginStudentAuth(c *gin.Context) {
 	var rpcResp *authproto.LoginStudentAuthResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("LoginStudentAuth", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.LoginStudentAuth(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -81,7 +81,7 @@ func (h *_default) LoginStudentAuth(c *gin.Context) {
 			msg = fmt.Sprintf("LoginStudentAuth returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -96,7 +96,7 @@ func (h *_default) LoginStudentAuth(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("LoginStudentAuth returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -113,15 +113,15 @@ func (h *_default) LoginStudentAuth(c *gin.Context) {
 			},
 		}, jwt.SigningMethodHS512)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "access_token": jwtToken, "student_uuid": rpcResp.LoggedInStudentUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "login_uuid": rpcResp.LoggedInStudentUUID,
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -147,12 +147,12 @@ func (h *_default) ChangeStudentPW(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.ChangeStudentPWRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -167,15 +167,15 @@ func (h *_default) ChangeStudentPW(c *gin.Context) {
 	var rpcResp *authproto.ChangeStudentPWResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("ChangeStudentPW", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.StudentUUID = c.Param("student_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.ChangeStudentPW(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -193,7 +193,7 @@ func (h *_default) ChangeStudentPW(c *gin.Context) {
 			msg = fmt.Sprintf("ChangeStudentPW returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -208,7 +208,7 @@ func (h *_default) ChangeStudentPW(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("ChangeStudentPW returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -218,14 +218,14 @@ func (h *_default) ChangeStudentPW(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := fmt.Sprintf("succeed to change auth password of %s", uuidClaims.UUID)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -248,10 +248,10 @@ func (h *_default) GetStudentInformWithUUID(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -266,15 +266,15 @@ func (h *_default) GetStudentInformWithUUID(c *gin.Context) {
 	var rpcResp *authproto.GetStudentInformWithUUIDResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetStudentInformWithUUID", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := new(authproto.GetStudentInformWithUUIDRequest)
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.StudentUUID = c.Param("student_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetStudentInformWithUUID(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -292,7 +292,7 @@ func (h *_default) GetStudentInformWithUUID(c *gin.Context) {
 			msg = fmt.Sprintf("GetStudentInformWithUUID returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -307,7 +307,7 @@ func (h *_default) GetStudentInformWithUUID(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetStudentInformWithUUID returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -321,14 +321,14 @@ func (h *_default) GetStudentInformWithUUID(c *gin.Context) {
 			"phone_number": rpcResp.PhoneNumber, "profile_uri": rpcResp.ImageURI, "parent_status": rpcResp.ParentStatus,
 			"grade": rpcResp.Grade, "group": rpcResp.Group, "student_number": rpcResp.StudentNumber,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
 	}
 
@@ -354,12 +354,12 @@ func (h *_default) GetStudentUUIDsWithInform(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetStudentUUIDsWithInformRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -374,14 +374,14 @@ func (h *_default) GetStudentUUIDsWithInform(c *gin.Context) {
 	var rpcResp *authproto.GetStudentUUIDsWithInformResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetStudentUUIDsWithInform", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetStudentUUIDsWithInform(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -399,7 +399,7 @@ func (h *_default) GetStudentUUIDsWithInform(c *gin.Context) {
 			msg = fmt.Sprintf("GetStudentUUIDsWithInform returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -414,7 +414,7 @@ func (h *_default) GetStudentUUIDsWithInform(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetStudentUUIDsWithInform returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -424,14 +424,14 @@ func (h *_default) GetStudentUUIDsWithInform(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to get student uuid list with inform"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "student_uuids": rpcResp.StudentUUIDs}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -457,12 +457,12 @@ func (h *_default) GetStudentInformsWithUUIDs(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetStudentInformsWithUUIDsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -477,14 +477,14 @@ func (h *_default) GetStudentInformsWithUUIDs(c *gin.Context) {
 	var rpcResp *authproto.GetStudentInformsWithUUIDsResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetStudentInformsWithUUIDs", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetStudentInformsWithUUIDs(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -502,7 +502,7 @@ func (h *_default) GetStudentInformsWithUUIDs(c *gin.Context) {
 			msg = fmt.Sprintf("GetStudentInformsWithUUIDs returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -517,7 +517,7 @@ func (h *_default) GetStudentInformsWithUUIDs(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetStudentInformsWithUUIDs returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -539,14 +539,14 @@ func (h *_default) GetStudentInformsWithUUIDs(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "students": students}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -569,10 +569,10 @@ func (h *_default) GetParentWithStudentUUID(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -587,15 +587,15 @@ func (h *_default) GetParentWithStudentUUID(c *gin.Context) {
 	var rpcResp *authproto.GetParentWithStudentUUIDResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetParentWithStudentUUID", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := new(authproto.GetParentWithStudentUUIDRequest)
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.StudentUUID = c.Param("student_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetParentWithStudentUUID(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -613,7 +613,7 @@ func (h *_default) GetParentWithStudentUUID(c *gin.Context) {
 			msg = fmt.Sprintf("GetParentWithStudentUUID returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -628,7 +628,7 @@ func (h *_default) GetParentWithStudentUUID(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetParentWithStudentUUID returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -641,14 +641,14 @@ func (h *_default) GetParentWithStudentUUID(c *gin.Context) {
 			"status": status, "code": _code, "message": msg,
 			"parent_uuid": rpcResp.ParentUUID, "name": rpcResp.Name, "phone_number": rpcResp.PhoneNumber,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
 	}
 
@@ -669,12 +669,12 @@ func (h *_default) GetUnsignedStudentWithAuthCode(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetUnsignedStudentWithAuthCodeRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -689,13 +689,13 @@ func (h *_default) GetUnsignedStudentWithAuthCode(c *gin.Context) {
 	var rpcResp *authproto.GetUnsignedStudentWithAuthCodeResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetStudentInformWithAuthCode", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetUnsignedStudentWithAuthCode(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -713,7 +713,7 @@ func (h *_default) GetUnsignedStudentWithAuthCode(c *gin.Context) {
 			msg = fmt.Sprintf("GetStudentInformWithAuthCode returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -728,7 +728,7 @@ func (h *_default) GetUnsignedStudentWithAuthCode(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetStudentInformWithAuthCode returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -741,14 +741,14 @@ func (h *_default) GetUnsignedStudentWithAuthCode(c *gin.Context) {
 			"name": rpcResp.Name, "phone_number": rpcResp.PhoneNumber,
 			"grade": rpcResp.Grade, "group": rpcResp.Group, "student_number": rpcResp.StudentNumber,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": rpcResp.Message, "request": string(reqBytes), "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -769,12 +769,12 @@ func (h *_default) CreateNewStudentWithAuthCode(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateNewStudentWithAuthCodeRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -789,13 +789,13 @@ func (h *_default) CreateNewStudentWithAuthCode(c *gin.Context) {
 	var rpcResp *authproto.CreateNewStudentWithAuthCodeResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("CreateNewStudentWithAuthCode", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.CreateNewStudentWithAuthCode(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -813,7 +813,7 @@ func (h *_default) CreateNewStudentWithAuthCode(c *gin.Context) {
 			msg = fmt.Sprintf("CreateNewStudentWithAuthCode returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -828,7 +828,7 @@ func (h *_default) CreateNewStudentWithAuthCode(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateNewStudentWithAuthCode returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -837,14 +837,14 @@ func (h *_default) CreateNewStudentWithAuthCode(c *gin.Context) {
 	case http.StatusCreated:
 		status, _code := http.StatusCreated, 0
 		sendResp := gin.H{"status": status, "code": _code, "message": rpcResp.Message, "student_uuid": rpcResp.StudentUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": rpcResp.Message, "request": string(reqBytes), "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	authproto "gateway/proto/golang/auth"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/dgrijalva/jwt-go"
@@ -18,7 +19,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -37,12 +37,12 @@ func (h *_default) CreateNewTeacher(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateNewTeacherRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -57,13 +57,13 @@ func (h *_default) CreateNewTeacher(c *gin.Context) {
 	var rpcResp *authproto.CreateNewTeacherResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("CreateNewTeacher", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.CreateNewTeacher(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -81,7 +81,7 @@ func (h *_default) CreateNewTeacher(c *gin.Context) {
 			msg = fmt.Sprintf("CreateNewTeacher returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -96,7 +96,7 @@ func (h *_default) CreateNewTeacher(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateNewTeacher returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -106,14 +106,14 @@ func (h *_default) CreateNewTeacher(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := "succeed to register teacher account. you can use it after approval"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "teacher_uuid": rpcResp.CreatedTeacherUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -134,12 +134,12 @@ func (h *_default) LoginTeacherAuth(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.LoginTeacherAuthRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -154,13 +154,13 @@ func (h *_default) LoginTeacherAuth(c *gin.Context) {
 	var rpcResp *authproto.LoginTeacherAuthResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("LoginTeacherAuth", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.LoginTeacherAuth(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -178,7 +178,7 @@ func (h *_default) LoginTeacherAuth(c *gin.Context) {
 			msg = fmt.Sprintf("LoginTeacherAuth returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -193,7 +193,7 @@ func (h *_default) LoginTeacherAuth(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("LoginTeacherAuth returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -210,15 +210,15 @@ func (h *_default) LoginTeacherAuth(c *gin.Context) {
 			},
 		}, jwt.SigningMethodHS512)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "access_token": jwtToken, "teacher_uuid": rpcResp.LoggedInTeacherUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "login_uuid": rpcResp.LoggedInTeacherUUID,
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -239,12 +239,12 @@ func (h *_default) LoginTeacherAuthWithPICK(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.LoginTeacherAuthWithPICKRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -259,13 +259,13 @@ func (h *_default) LoginTeacherAuthWithPICK(c *gin.Context) {
 	var rpcResp *authproto.LoginTeacherAuthWithPICKResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("LoginTeacherAuthWithPICK", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.LoginTeacherAuthWithPICK(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -283,7 +283,7 @@ func (h *_default) LoginTeacherAuthWithPICK(c *gin.Context) {
 			msg = fmt.Sprintf("LoginTeacherAuthWithPICK returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -298,7 +298,7 @@ func (h *_default) LoginTeacherAuthWithPICK(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("LoginTeacherAuthWithPICK returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -315,15 +315,15 @@ func (h *_default) LoginTeacherAuthWithPICK(c *gin.Context) {
 			},
 		}, jwt.SigningMethodHS512)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "access_token": jwtToken, "teacher_uuid": rpcResp.LoggedInTeacherUUID}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "login_uuid": rpcResp.LoggedInTeacherUUID,
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -349,12 +349,12 @@ func (h *_default) ChangeTeacherPW(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.ChangeTeacherPWRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -369,15 +369,15 @@ func (h *_default) ChangeTeacherPW(c *gin.Context) {
 	var rpcResp *authproto.ChangeTeacherPWResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("ChangeTeacherPW", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.TeacherUUID = c.Param("teacher_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.ChangeTeacherPW(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -395,7 +395,7 @@ func (h *_default) ChangeTeacherPW(c *gin.Context) {
 			msg = fmt.Sprintf("ChangeTeacherPW returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -410,7 +410,7 @@ func (h *_default) ChangeTeacherPW(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("ChangeTeacherPW returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -420,14 +420,14 @@ func (h *_default) ChangeTeacherPW(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := fmt.Sprintf("succeed to change auth password of %s", uuidClaims.UUID)
 		sendResp := gin.H{"status": status, "code": _code, "message": msg}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -450,10 +450,10 @@ func (h *_default) GetTeacherInformWithUUID(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -468,15 +468,15 @@ func (h *_default) GetTeacherInformWithUUID(c *gin.Context) {
 	var rpcResp *authproto.GetTeacherInformWithUUIDResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetTeacherInformWithUUID", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := new(authproto.GetTeacherInformWithUUIDRequest)
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.TeacherUUID = c.Param("teacher_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetTeacherInformWithUUID(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -494,7 +494,7 @@ func (h *_default) GetTeacherInformWithUUID(c *gin.Context) {
 			msg = fmt.Sprintf("GetTeacherInformWithUUID returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -509,7 +509,7 @@ func (h *_default) GetTeacherInformWithUUID(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetTeacherInformWithUUID returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -523,14 +523,14 @@ func (h *_default) GetTeacherInformWithUUID(c *gin.Context) {
 			"name": rpcResp.Name, "phone_number": rpcResp.PhoneNumber,
 			"grade": rpcResp.Grade, "group": rpcResp.Group,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
 	}
 
@@ -556,12 +556,12 @@ func (h *_default) GetTeacherUUIDsWithInform(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetTeacherUUIDsWithInformRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -576,14 +576,14 @@ func (h *_default) GetTeacherUUIDsWithInform(c *gin.Context) {
 	var rpcResp *authproto.GetTeacherUUIDsWithInformResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("GetTeacherUUIDsWithInform", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.GetTeacherUUIDsWithInform(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -601,7 +601,7 @@ func (h *_default) GetTeacherUUIDsWithInform(c *gin.Context) {
 			msg = fmt.Sprintf("GetTeacherUUIDsWithInform returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -616,7 +616,7 @@ func (h *_default) GetTeacherUUIDsWithInform(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetTeacherUUIDsWithInform returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -626,14 +626,14 @@ func (h *_default) GetTeacherUUIDsWithInform(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to get teacher uuid list with inform"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "teacher_uuids": rpcResp.TeacherUUIDs}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -659,12 +659,12 @@ func (h *_default) ChangeTeacherInform(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.ChangeTeacherInformRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -679,15 +679,15 @@ func (h *_default) ChangeTeacherInform(c *gin.Context) {
 	var rpcResp *authproto.ChangeTeacherInformResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		authSrvSpan := h.tracer.StartSpan("ChangeTeacherInform", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.UUID = uuidClaims.UUID
 		rpcReq.TeacherUUID = c.Param("teacher_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.authService.ChangeTeacherInform(ctxForReq, rpcReq, callOpts...)
-		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		authSrvSpan.Finish()
 		return
 	})
@@ -705,7 +705,7 @@ func (h *_default) ChangeTeacherInform(c *gin.Context) {
 			msg = fmt.Sprintf("ChangeTeacherInform returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -720,7 +720,7 @@ func (h *_default) ChangeTeacherInform(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("ChangeTeacherInform returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -729,14 +729,14 @@ func (h *_default) ChangeTeacherInform(c *gin.Context) {
 	case http.StatusOK:
 		status, _code, msg := http.StatusOK, 0, "succeed to change teacher inform"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
@@ -0,0 +1,307 @@
+// add file in v.1.0.6
+// default_dashboard.go is file that declare handler aggregating responses of several services into one dashboard response
+// each section of dashboard is response of underlying API handler run concurrently with copied context
+
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/entity"
+	entityregistry "gateway/entity/registry"
+	"gateway/middleware"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dashboardSection is underlying API handler to run & setting of context used in running it
+type dashboardSection struct {
+	name     string          // key of section in dashboard response
+	handler  gin.HandlerFunc // handler of underlying API
+	params   gin.Params      // uri parameters of underlying API
+	request  interface{}     // request entity of underlying API which is bound in RequestValidator normally
+	redisKey string          // redis key of underlying API response cache, not used if blank
+	timeout  time.Duration
+}
+
+func (h *_default) GetStudentDashboard(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	studentUUID := c.Param("student_uuid")
+	now := time.Now().In(h.location)
+	outingsReq := &entity.GetStudentOutingsRequest{}
+	_ = entityregistry.SetDefaultValues(outingsReq) // start, count not sent in dashboard request are same with default of API (add in v.1.0.6)
+	timeTableReq := &entity.GetTimeTableRequest{Year: int32(now.Year()), Month: int32(now.Month()), Day: int32(now.Day()), Count: 1}
+
+	// redis keys are formatted in same way as RedisHandler middleware of underlying API
+	// cache about student uuid is used only if student get own dashboard (key is blank), same as RedisHandler middleware
+	outingsKey, _ := middleware.FormatRedisKey(middleware.StudentOutingsKey, c, outingsReq, uuidClaims)
+	announcementCheckKey, _ := middleware.FormatRedisKey(middleware.AnnouncementCheckKey, c, nil, uuidClaims)
+	timeTableKey, _ := middleware.FormatRedisKey(middleware.TimeTableKey, c, timeTableReq, uuidClaims)
+
+	sections := []dashboardSection{{
+		name:    "student",
+		handler: h.GetStudentInformWithUUID,
+		params:  gin.Params{{Key: "student_uuid", Value: studentUUID}},
+		timeout: h.config.DashboardSectionTimeout,
+	}, {
+		name:     "outings",
+		handler:  h.GetStudentOutings,
+		params:   gin.Params{{Key: "student_uuid", Value: studentUUID}},
+		request:  outingsReq,
+		redisKey: outingsKey,
+		timeout:  h.config.DashboardSectionTimeout,
+	}, {
+		name:     "time_table",
+		handler:  h.GetTimeTable,
+		request:  timeTableReq,
+		redisKey: timeTableKey,
+		timeout:  h.config.DashboardSectionTimeout,
+	}, {
+		name:     "announcement_check",
+		handler:  h.CheckAnnouncement,
+		params:   gin.Params{{Key: "student_uuid", Value: studentUUID}},
+		redisKey: announcementCheckKey,
+		timeout:  h.config.DashboardSectionTimeout,
+	}, {
+		name:    "club",
+		handler: h.GetClubUUIDWithLeaderUUID,
+		params:  gin.Params{{Key: "leader_uuid", Value: studentUUID}},
+		timeout: h.config.DashboardSectionTimeout,
+	}}
+
+	results := make([]gin.H, len(sections))
+	wg := sync.WaitGroup{}
+	for i, section := range sections {
+		// context must be copied in this goroutine because copying reads keys of context
+		sub := dashboardSubContext(c, entry, section.name)
+		wg.Add(1)
+		go func(i int, section dashboardSection, sub *gin.Context) {
+			defer wg.Done()
+			results[i] = h.runDashboardSection(sub, section)
+		}(i, section, sub)
+	}
+	wg.Wait()
+
+	sendResp := gin.H{}
+	var failed []string
+	for i, section := range sections {
+		sendResp[section.name] = results[i]
+		if status := sectionStatus(results[i]); status == http.StatusRequestTimeout || status >= http.StatusInternalServerError {
+			failed = append(failed, section.name)
+		}
+	}
+
+	var status, _code int
+	var msg string
+	switch len(failed) {
+	case 0:
+		status, _code = http.StatusOK, 0
+		msg = fmt.Sprintf("succeed to get student dashboard, student uuid: %s", studentUUID)
+	case len(sections):
+		student := results[0]
+		status, _code = sectionStatus(student), 0
+		if value, ok := student["code"].(float64); ok {
+			_code = int(value)
+		}
+		msg = fmt.Sprintf("all sections of student dashboard failed, message of student section: %v", student["message"])
+	default:
+		status, _code = http.StatusOK, gwcode.PartialDashboardSections
+		msg = fmt.Sprintf("some sections of student dashboard failed, sections: %s", strings.Join(failed, ", "))
+	}
+	sendResp["status"], sendResp["code"], sendResp["message"] = status, _code, msg
+
+	envelope.JSON(c, status, sendResp)
+	if len(failed) == 0 {
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Info()
+	} else {
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Warn()
+	}
+	return
+}
+
+// return cached response of section if exists, or run handler of section with timeout & return response written by handler
+// rpc called in handler of section is canceled with context of section when section is timed out (change in v.1.0.6)
+func (h *_default) runDashboardSection(sub *gin.Context, section dashboardSection) (resp gin.H) {
+	sectionCtx, cancel := context.WithTimeout(sub.Request.Context(), section.timeout)
+	defer cancel()
+	sub.Set("RPCContext", sectionCtx)
+
+	if section.redisKey != "" {
+		if value, err := h.redisClient.Get(sectionCtx, section.redisKey).Result(); err == nil {
+			cached := gin.H{}
+			if err := json.Unmarshal([]byte(value), &cached); err == nil {
+				return cached
+			}
+		}
+	}
+
+	writer := newSectionWriter()
+	sub.Writer = writer
+	sub.Params = section.params
+	if section.request != nil {
+		sub.Set("Request", section.request)
+	} else {
+		delete(sub.Keys, "Request")
+	}
+
+	done := make(chan gin.H, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				msg := fmt.Sprintf("panic occurs while running %s section of dashboard, err: %v", section.name, r)
+				done <- gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg}
+			}
+		}()
+		section.handler(sub)
+		done <- nil
+	}()
+
+	select {
+	case resp = <-done:
+		if resp != nil {
+			return
+		}
+	case <-sectionCtx.Done():
+		// handler still running owns sub context from now on, so it is not read here & writes of handler are discarded
+		writer.detach()
+		msg := fmt.Sprintf("request time out for %s section of dashboard, time out: %s", section.name, section.timeout.String())
+		return gin.H{"status": http.StatusRequestTimeout, "code": 0, "message": msg}
+	}
+
+	// read envelope written by handler of section in sub context instead of unmarshalling response body (change in v.1.0.6)
+	sectionResp, ok := envelope.FromContext(sub)
+	if !ok {
+		msg := fmt.Sprintf("response of %s section of dashboard is not envelope, body: %s", section.name, writer.String())
+		return gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg}
+	}
+	resp = sectionResp.Map()
+
+	// set response in redis key in same way as event published by RedisHandler middleware of underlying API
+	if section.redisKey != "" && writer.Status() == http.StatusOK {
+		resp["redis.key"] = section.redisKey
+		payload, _ := json.Marshal(resp)
+		delete(resp, "redis.key")
+		_ = h.SetRedisKeyWithResponse(&redis.Message{Payload: string(payload)})
+	}
+	return
+}
+
+// return copy of context to run handler of section in, log entry of which is marked with section name
+func dashboardSubContext(c *gin.Context, entry *logrus.Entry, name string) (sub *gin.Context) {
+	sub = c.Copy()
+	sub.Set("RequestLogEntry", entry.WithField("dashboard_section", name))
+	return
+}
+
+// return status field of section response, which is float64 if unmarshalled from json
+func sectionStatus(resp gin.H) int {
+	switch status := resp["status"].(type) {
+	case int:
+		return status
+	case float64:
+		return int(status)
+	}
+	return http.StatusInternalServerError
+}
+
+// sectionWriter is response writer saving response of section handler in memory instead of sending it to client
+// it implements all methods of gin.ResponseWriter, so flushing or hijacking in section doesn't panic with nil writer
+// writer is detached when section is timed out, then writes of handler still running are discarded (change in v.1.0.6)
+type sectionWriter struct {
+	mutex       sync.Mutex
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	detached    bool
+	closeNotify chan bool
+}
+
+func newSectionWriter() *sectionWriter {
+	return &sectionWriter{header: http.Header{}, closeNotify: make(chan bool, 1)}
+}
+
+func (w *sectionWriter) Header() http.Header { return w.header }
+
+func (w *sectionWriter) WriteHeader(status int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.detached {
+		w.status = status
+	}
+}
+
+func (w *sectionWriter) WriteHeaderNow() {}
+
+func (w *sectionWriter) Write(b []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.detached {
+		return len(b), nil
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *sectionWriter) WriteString(s string) (int, error) { return w.Write([]byte(s)) }
+
+func (w *sectionWriter) Status() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.status
+}
+
+func (w *sectionWriter) Size() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.body.Len()
+}
+
+func (w *sectionWriter) Written() bool { return w.Status() != 0 }
+
+// return body written by handler of section
+func (w *sectionWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.body.String()
+}
+
+// response of section is not sent to client, so flush, hijack & push are not supported
+func (w *sectionWriter) Flush()                   {}
+func (w *sectionWriter) Pusher() http.Pusher      { return nil }
+func (w *sectionWriter) CloseNotify() <-chan bool { return w.closeNotify }
+func (w *sectionWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("hijacking response writer of dashboard section is not supported")
+}
+
+// discard writes of handler after section is timed out & notify handler waiting with CloseNotify
+func (w *sectionWriter) detach() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if !w.detached {
+		w.detached = true
+		w.closeNotify <- true
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"gateway/tool/compress"
+	"gateway/tool/envelope"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
@@ -67,12 +69,37 @@ func (h *_default) SetRedisKeyWithResponse(msg *redis.Message) (err error) {
 	delete(resp, "redis.key")
 	respBytes, _ := json.Marshal(resp)
 
-	result, err := h.redisClient.Set(ctx, key, string(respBytes), time.Minute).Result()
-	if err != nil {
+	// response, ETag & precompressed response are written in one transaction with same TTL, so ResponderIfKeyExist
+	// never reads ETag or gzip body of other response (change in v.1.0.6)
+	ttl := time.Minute
+	if timetableRegex.MatchString(key) {
+		ttl = time.Hour * 24
+	}
+	pipe := h.redisClient.TxPipeline()
+	pipe.Set(ctx, key, string(respBytes), ttl)
+	// ETag of response is compared with If-None-Match header in ResponderIfKeyExist (add in v.1.0.6)
+	// respBytes is canonical json, so ETag is the same with one computed over response body sent in cache miss (change in v.1.0.6)
+	pipe.Set(ctx, envelope.ETagKey(key), envelope.ETag(envelope.CanonicalJSON(respBytes)), ttl)
+	// response precompressed with gzip is responded in ResponderIfKeyExist without compressing again (add in v.1.0.6)
+	// precompressed response of previous response is deleted if response is not compressed
+	var compressed []byte
+	if len(respBytes) >= h.compressionMinSize {
+		var compressErr error
+		if compressed, compressErr = compress.Compress(compress.Gzip, respBytes); compressErr != nil {
+			log.Errorf("unable to compress response set in redis key, key: %s, err: %v", key, compressErr)
+			compressed = nil
+		}
+	}
+	if compressed != nil {
+		pipe.Set(ctx, compress.PrecompressedKey(key), compressed, ttl)
+	} else {
+		pipe.Del(ctx, compress.PrecompressedKey(key))
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
 		err = errors.New(fmt.Sprintf("unable to set response in redis key, err: %v", err))
 		return
 	}
-	log.Infof("succeed to set response in redis key!, key: %s, result: %s", key, result)
+	log.Infof("succeed to set response in redis key!, key: %s", key)
 
 	switch true {
 	case outingRegex.MatchString(key):
@@ -95,8 +122,6 @@ func (h *_default) SetRedisKeyWithResponse(msg *redis.Message) (err error) {
 		}
 		key = fmt.Sprintf("%s.type", key)
 		h.redisClient.Set(ctx, key, _type, 0)
-	case timetableRegex.MatchString(key):
-		h.redisClient.Set(ctx, key, string(respBytes), time.Hour * 24)
 	}
 	return
 }
@@ -157,6 +182,10 @@ func (h *_default) DeleteAssociatedRedisKey(msg *redis.Message) (err error) {
 	case regexp.MustCompile("students.*.timetable.*").MatchString(payload):
 		pattern = "students.*.timetable.*"
 
+	case announcementTargetRegex.MatchString(payload):
+		// ex) announcements.types.school.grades.12.groups.0 -> no key to delete, only used in PublishStudentEvent (add in v.1.0.6)
+		return
+
 	default:
 		err = errors.New(fmt.Sprintf("message does not match any regular expressions, msg payload: %s", payload))
 		return
@@ -182,7 +211,8 @@ func (h *_default) deleteRedisKeyWithPattern(pattern string) (num int, err error
 	num = len(keys)
 
 	for _, key := range keys {
-		if _, err = h.redisClient.Del(ctx, key).Result(); err != nil {
+		// precompressed response & ETag of key are also deleted (change in v.1.0.6)
+		if _, err = h.redisClient.Del(ctx, key, compress.PrecompressedKey(key), envelope.ETagKey(key)).Result(); err != nil {
 			err = errors.New(fmt.Sprintf("unable to execute redis DEL cmd, key: %s, err: %v", key, err))
 			return
 		}
@@ -33,7 +33,7 @@ func (h *_default) PublishConsulChangeEvent (c *gin.Context) {
 	}
 
 	switch true {
-	case c.GetHeader(consulIndexHeader) != "":
+	case c.GetHeader(h.config.ConsulIndexHeader) != "":
 	default:
 		c.AbortWithStatusJSON(http.StatusProxyAuthRequired, respFor407)
 		return
@@ -46,7 +46,7 @@ func (h *_default) PublishConsulChangeEvent (c *gin.Context) {
 	}
 
 	service := serviceName(c.GetHeader("Service"))
-	index := consulIndex(c.GetHeader(consulIndexHeader))
+	index := consulIndex(c.GetHeader(h.config.ConsulIndexHeader))
 
 	consulIndexMutex.Lock()
 	if _, exist := h.consulIndexFilter[service][index]; exist {
@@ -64,7 +64,7 @@ func (h *_default) PublishConsulChangeEvent (c *gin.Context) {
 
 	//pubOutput, err := sns.New(h.awsSession).Publish(&sns.PublishInput{
 	//	Message:  aws.String("ConsulChangeEvent"),
-	//	TopicArn: aws.String(snsTopicArn),
+	//	TopicArn: aws.String(h.config.SNSTopicArn),
 	//})
 	//if err != nil {
 	//	c.JSON(http.StatusInternalServerError, err.Error())
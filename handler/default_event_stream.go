@@ -0,0 +1,443 @@
+// add file in v.1.0.6
+// default_event_stream.go is file that declare Server-Sent Events stream pushing outing, announcement, schedule change to student
+// delete key events published in DeleteKeyEventPublisher are converted to student events & appended to redis stream once,
+// and each gateway reads the stream & sends events to connections held by itself, so Last-Event-ID is valid in any gateway
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	authproto "gateway/proto/golang/auth"
+	outingproto "gateway/proto/golang/outing"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/keyevent"
+	topic "gateway/utils/topic/golang"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/micro/go-micro/v2/client"
+	log "github.com/micro/go-micro/v2/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	studentEventStreamKey    = "events.students"       // redis stream storing student events in order
+	studentEventStreamMaxLen = 10000                   // approximate max length of redis stream, old events are trimmed
+	studentEventDedupFormat  = "events.dedup.%s"       // key set by gateway which appends event of delete key message first
+	studentEventDedupWindow  = time.Second * 5         // all gateways receive same message within this window
+	eventConnectionsFormat   = "events.connections.%s" // sorted set of connections per user, score is expiration time
+	eventHeartbeatInterval   = time.Second * 15        // interval of comment sent to keep connection & refresh expiration
+	eventSubscriberBuffer    = 64                      // connection is closed if this number of events are not sent yet
+	eventConnectionTTL       = eventHeartbeatInterval * 3
+)
+
+var (
+	// key published in CreateAnnouncement only to notify target of new announcement, ex) announcements.types.school.grades.12.groups.0
+	announcementTargetRegex = regexp.MustCompile("^announcements.types.(school|club).grades.\\d+.groups.\\d+$")
+)
+
+// studentEvent is event sent to student in event stream, ID is id of entry in redis stream
+type studentEvent struct {
+	ID          string
+	Type        string // outing, announcement, schedule
+	StudentUUID string // empty if event is sent to all students matched with target grade, group
+	TargetGrade int    // 0 means all grades, otherwise digits of target grades, ex) 12 -> 1, 2 grade
+	TargetGroup int    // 0 means all groups, otherwise digits of target groups
+	Data        string // json object sent in data field
+}
+
+// studentEventSubscriber is one event stream connection of student (or parent of student)
+type studentEventSubscriber struct {
+	studentUUID string
+	grade       int
+	group       int
+	events      chan studentEvent
+}
+
+// return true if event should be sent to student of this subscriber
+func (s *studentEventSubscriber) matches(event studentEvent) bool {
+	if event.StudentUUID != "" {
+		return event.StudentUUID == s.studentUUID
+	}
+	return targetContains(event.TargetGrade, s.grade) && targetContains(event.TargetGroup, s.group)
+}
+
+func targetContains(target, value int) bool {
+	return target == 0 || strings.Contains(strconv.Itoa(target), strconv.Itoa(value))
+}
+
+// studentEventHub send event read from redis stream to subscribers connected to this gateway
+// done is closed in shutdown of server so that long-lived event streams are finished while other requests are drained
+type studentEventHub struct {
+	mutex       sync.Mutex
+	subscribers map[*studentEventSubscriber]struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func newStudentEventHub() *studentEventHub {
+	return &studentEventHub{subscribers: map[*studentEventSubscriber]struct{}{}, done: make(chan struct{})}
+}
+
+func (h *studentEventHub) close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+func (h *studentEventHub) subscribe(s *studentEventSubscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.subscribers[s] = struct{}{}
+}
+
+func (h *studentEventHub) unsubscribe(s *studentEventSubscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.events)
+	}
+}
+
+// send event to matched subscribers, subscriber which doesn't receive events in time is removed (channel is closed)
+// client of removed subscriber can reconnect with Last-Event-ID & receive missed events again
+func (h *studentEventHub) dispatch(event studentEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for s := range h.subscribers {
+		if !s.matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			delete(h.subscribers, s)
+			close(s.events)
+		}
+	}
+}
+
+// convert delete redis key message to student event & append it to redis stream (run as redis listener of delete topic)
+// only one gateway which sets dedup key first appends event, because all gateways receive same message
+func (h *_default) PublishStudentEvent(msg *redis.Message) (err error) {
+	redisKey, reqID, userUUID := keyevent.Parse(msg.Payload)
+	payload := paramStringRegex.ReplaceAllStringFunc(redisKey, func(param string) string {
+		param = strings.TrimSuffix(strings.TrimPrefix(param, "{"), "}")
+		value, err := h.redisClient.Get(ctx, param).Result()
+		if err != nil {
+			return "*"
+		}
+		return value
+	})
+
+	event := studentEvent{}
+	switch true {
+	case outingRegex.MatchString(payload):
+		// student uuid of outing is set in SetRedisKeyWithResponse when outing inform is responded
+		// or got from outing service with uuid of user who changed outing if not set yet (change in v.1.0.6)
+		sid, err := h.redisClient.Get(ctx, fmt.Sprintf("%s.student_uuid", payload)).Result()
+		if err == redis.Nil {
+			sid, err = h.getStudentUUIDOfOuting(strings.TrimPrefix(payload, "outings."), reqID, userUUID)
+		}
+		if err != nil {
+			err = errors.New(fmt.Sprintf("unable to get student uuid of outing, payload: %s, err: %v", payload, err))
+			return err
+		}
+		dataBytes, _ := json.Marshal(gin.H{"outing_uuid": strings.TrimPrefix(payload, "outings."), "student_uuid": sid})
+		event = studentEvent{Type: "outing", StudentUUID: sid, Data: string(dataBytes)}
+
+	case announcementTargetRegex.MatchString(payload):
+		// ex) announcements.types.school.grades.12.groups.0
+		separated := strings.Split(payload, ".")
+		event.TargetGrade, _ = strconv.Atoi(separated[4])
+		event.TargetGroup, _ = strconv.Atoi(separated[6])
+		dataBytes, _ := json.Marshal(gin.H{"type": separated[2]})
+		event.Type, event.Data = "announcement", string(dataBytes)
+
+	case schedulesRegex.MatchString(payload):
+		event = studentEvent{Type: "schedule", Data: "{}"}
+
+	default:
+		return nil
+	}
+
+	// dedup with request id, so that changes of same key in different requests are not collapsed (change in v.1.0.6)
+	ok, err := h.redisClient.SetNX(ctx, fmt.Sprintf(studentEventDedupFormat, redisKey+"."+reqID), 1, studentEventDedupWindow).Result()
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to set dedup key of student event, err: %v", err))
+		return
+	}
+	if !ok {
+		return nil
+	}
+
+	id, err := h.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream:       studentEventStreamKey,
+		MaxLenApprox: studentEventStreamMaxLen,
+		Values: map[string]interface{}{
+			"type":         event.Type,
+			"student_uuid": event.StudentUUID,
+			"target_grade": event.TargetGrade,
+			"target_group": event.TargetGroup,
+			"data":         event.Data,
+		},
+	}).Result()
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to append student event to redis stream, err: %v", err))
+		return
+	}
+	log.Infof("succeed to append student event to redis stream!, msg payload: %s, type: %s, id: %s", payload, event.Type, id)
+	return
+}
+
+// return student uuid of outing got from outing service, which is also set in redis key like SetRedisKeyWithResponse
+// outing service checks if user can read inform of outing, so user who changed outing is used in request (add in v.1.0.6)
+func (h *_default) getStudentUUIDOfOuting(outingUUID, reqID, userUUID string) (string, error) {
+	if userUUID == "" {
+		return "", errors.New("uuid of user who changed outing is not in delete key message")
+	}
+
+	topSpan := h.tracer.StartSpan("PublishStudentEvent").SetTag("X-Request-Id", reqID)
+	defer topSpan.Finish()
+
+	rpcReq := new(outingproto.GetOutingInformRequest)
+	rpcReq.Uuid = userUUID
+	rpcReq.OutingId = outingUUID
+	var rpcResp *outingproto.GetOutingInformResponse
+	if err := h.callService(ctx, topic.OutingServiceName, "GetOutingInform", reqID, topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.outingService.GetOutingInform(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return "", err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("GetOutingInform returns status %d, code: %d, message: %s", rpcResp.Status, rpcResp.Code, rpcResp.Msg))
+	}
+	if !studentUUIDRegex.MatchString(rpcResp.StudentUuid) {
+		return "", errors.New(fmt.Sprintf("GetOutingInform returns invalid student uuid, student uuid: %s", rpcResp.StudentUuid))
+	}
+
+	h.redisClient.Set(ctx, fmt.Sprintf("outings.%s.student_uuid", outingUUID), rpcResp.StudentUuid, 0)
+	return rpcResp.StudentUuid, nil
+}
+
+// read student events appended to redis stream & dispatch to connections of this gateway (run as listener of subscriber)
+func (h *_default) ListenStudentEvents() {
+	lastID := "$"
+	for {
+		streams, err := h.redisClient.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{studentEventStreamKey, lastID},
+			Count:   100,
+			Block:   time.Second * 5,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			log.Errorf("some error occurs while reading student events from redis stream, err: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				lastID = message.ID
+				h.eventHub.dispatch(studentEventFromMessage(message))
+			}
+		}
+	}
+}
+
+func studentEventFromMessage(message redis.XMessage) (event studentEvent) {
+	event.ID = message.ID
+	event.Type, _ = message.Values["type"].(string)
+	event.StudentUUID, _ = message.Values["student_uuid"].(string)
+	grade, _ := message.Values["target_grade"].(string)
+	event.TargetGrade, _ = strconv.Atoi(grade)
+	group, _ := message.Values["target_group"].(string)
+	event.TargetGroup, _ = strconv.Atoi(group)
+	event.Data, _ = message.Values["data"].(string)
+	return
+}
+
+// compare id of redis stream entry (<millisecondsTime>-<sequenceNumber>), return -1, 0, 1
+func compareStreamID(a, b string) int {
+	parse := func(id string) (ms, seq uint64) {
+		separated := strings.SplitN(id, "-", 2)
+		ms, _ = strconv.ParseUint(separated[0], 10, 64)
+		if len(separated) == 2 {
+			seq, _ = strconv.ParseUint(separated[1], 10, 64)
+		}
+		return
+	}
+	aMs, aSeq := parse(a)
+	bMs, bSeq := parse(b)
+	switch {
+	case aMs < bMs || (aMs == bMs && aSeq < bSeq):
+		return -1
+	case aMs == bMs && aSeq == bSeq:
+		return 0
+	}
+	return 1
+}
+
+// close event streams connected to this gateway, client reconnects to other gateway with Last-Event-ID (run on server shutdown)
+func (h *_default) CloseStudentEvents() {
+	h.eventHub.close()
+}
+
+func (h *_default) GetStudentEvents(c *gin.Context) {
+	reqID := c.GetHeader("X-Request-Id")
+
+	// get top span from middleware
+	inAdvanceTopSpan, _ := c.Get("TopSpan")
+	topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// check number of connections of user across all gateways, expired connections (not refreshed with heartbeat) are removed
+	connKey := fmt.Sprintf(eventConnectionsFormat, uuidClaims.UUID)
+	now := time.Now()
+	pipe := h.redisClient.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, connKey, "-inf", strconv.FormatInt(now.Unix(), 10))
+	pipe.ZAdd(ctx, connKey, &redis.Z{Score: float64(now.Add(eventConnectionTTL).Unix()), Member: reqID})
+	pipe.Expire(ctx, connKey, eventConnectionTTL)
+	countCmd := pipe.ZCard(ctx, connKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		status, _code := http.StatusInternalServerError, 0
+		msg := fmt.Sprintf("unable to count event stream connections of user, err: %v", err)
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
+		return
+	}
+	defer h.redisClient.ZRem(ctx, connKey, reqID)
+	if countCmd.Val() > int64(h.config.EventStreamMaxConnections) {
+		status, _code := http.StatusTooManyRequests, gwcode.TooManyEventStreams
+		msg := fmt.Sprintf("user already has max number of event stream connections, max: %d", h.config.EventStreamMaxConnections)
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Info()
+		return
+	}
+
+	// auth service checks if user can read inform of student, same with GET /v1/students/uuid/:student_uuid
+	rpcReq := new(authproto.GetStudentInformWithUUIDRequest)
+	rpcReq.UUID = uuidClaims.UUID
+	rpcReq.StudentUUID = c.Param("student_uuid")
+	var rpcResp *authproto.GetStudentInformWithUUIDResponse
+	if err := h.callService(c.Request.Context(), topic.AuthServiceName, "GetStudentInformWithUUID", reqID, topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.authService.GetStudentInformWithUUID(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		srvErr, _ := err.(*serviceError)
+		envelope.JSON(c, srvErr.status, gin.H{"status": srvErr.status, "code": srvErr.code, "message": srvErr.localizedMessage(c.GetHeader("Accept-Language"))})
+		entry.WithFields(logrus.Fields{"status": srvErr.status, "code": srvErr.code, "message": srvErr.message}).Error()
+		return
+	}
+	switch rpcResp.Status {
+	case http.StatusOK:
+		break
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Error()
+		return
+	default:
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message}).Info()
+		return
+	}
+
+	// subscribe before reading missed events, so that events appended while reading are not lost
+	subscriber := &studentEventSubscriber{
+		studentUUID: c.Param("student_uuid"),
+		grade:       int(rpcResp.Grade),
+		group:       int(rpcResp.Group),
+		events:      make(chan studentEvent, eventSubscriberBuffer),
+	}
+	h.eventHub.subscribe(subscriber)
+	defer h.eventHub.unsubscribe(subscriber)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	writeEvent := func(event studentEvent) {
+		_, _ = fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	}
+
+	// send events after Last-Event-ID, or resync event if events after that id were already trimmed from redis stream
+	sentID := ""
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	if lastEventID != "" {
+		sentID = lastEventID
+		messages, err := h.redisClient.XRange(ctx, studentEventStreamKey, lastEventID, "+").Result()
+		switch {
+		case err != nil:
+			entry.WithField("last_event_id", lastEventID).Warnf("unable to read missed student events, err: %v", err)
+		case len(messages) == 0 || messages[0].ID != lastEventID:
+			first := h.redisClient.XRangeN(ctx, studentEventStreamKey, "-", "+", 1).Val()
+			if len(first) == 0 || compareStreamID(first[0].ID, lastEventID) > 0 {
+				writeEvent(studentEvent{ID: lastEventID, Type: "resync", Data: "{}"})
+			}
+		}
+		for _, message := range messages {
+			if event := studentEventFromMessage(message); compareStreamID(event.ID, lastEventID) > 0 && subscriber.matches(event) {
+				writeEvent(event)
+				sentID = event.ID
+			}
+		}
+	}
+	c.Writer.Flush()
+	entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": "succeed to open student event stream", "last_event_id": lastEventID}).Info()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": "student event stream is closed by client", "last_event_id": sentID}).Info()
+			return
+		case <-h.eventHub.done:
+			entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": "student event stream is closed because server is shutting down", "last_event_id": sentID}).Info()
+			return
+		case event, ok := <-subscriber.events:
+			if !ok {
+				entry.WithFields(logrus.Fields{"status": http.StatusOK, "code": 0, "message": "student event stream is closed because events are not sent in time", "last_event_id": sentID}).Warn()
+				return
+			}
+			if sentID != "" && compareStreamID(event.ID, sentID) <= 0 {
+				continue
+			}
+			writeEvent(event)
+			sentID = event.ID
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			h.redisClient.ZAdd(ctx, connKey, &redis.Z{Score: float64(time.Now().Add(eventConnectionTTL).Unix()), Member: reqID})
+			h.redisClient.Expire(ctx, connKey, eventConnectionTTL)
+			_, _ = fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
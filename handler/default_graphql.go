@@ -0,0 +1,602 @@
+// add file in v.1.0.6
+// default_graphql.go is file that declare GraphQL facade handler & schema which resolvers call same services with other handlers
+// informs of students & clubs are loaded with loader, so that uuids requested in same level are batched into one rpc call
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"gateway/entity"
+	announcementproto "gateway/proto/golang/announcement"
+	authproto "gateway/proto/golang/auth"
+	clubproto "gateway/proto/golang/club"
+	outingproto "gateway/proto/golang/outing"
+	scheduleproto "gateway/proto/golang/schedule"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	"gateway/tool/graphql"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	topic "gateway/utils/topic/golang"
+	"github.com/gin-gonic/gin"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	graphQLLoaderWait     = time.Millisecond * 2 // time to wait for other keys before dispatching batch of loader
+	graphQLLoaderMaxBatch = 100                  // max number of uuids in one batch rpc call
+)
+
+type graphQLContextKey struct{}
+
+// graphQLRequestContext is state of one GraphQL request passed to resolvers through context
+type graphQLRequestContext struct {
+	reqID    string
+	topSpan  opentracing.Span
+	claims   jwtutil.UUIDClaims
+	students *graphql.Loader
+	clubs    *graphql.Loader
+	parents  *graphql.Loader // loader of parents with student uuids, used in outing dashboard
+}
+
+func graphQLRequestContextFrom(ctx context.Context) *graphQLRequestContext {
+	reqCtx, _ := ctx.Value(graphQLContextKey{}).(*graphQLRequestContext)
+	return reqCtx
+}
+
+func (h *_default) GraphQL(c *gin.Context) {
+	reqID := c.GetHeader("X-Request-Id")
+
+	// get top span from middleware
+	inAdvanceTopSpan, _ := c.Get("TopSpan")
+	topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.GraphQLRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	query := receivedReq.Query
+	if persisted := receivedReq.Extensions.PersistedQuery; persisted != nil {
+		entry = entry.WithField("persisted_query", persisted.SHA256Hash)
+		if stored, ok := h.graphQLPersistedQueries.Lookup(persisted.SHA256Hash); ok {
+			query = stored
+		} else if query == "" {
+			status, _code := http.StatusNotFound, gwcode.PersistedQueryNotFound
+			msg := fmt.Sprintf("persisted query is not found, hash: %s", persisted.SHA256Hash)
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "errors": []gin.H{{"message": "PersistedQueryNotFound"}}})
+			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+			return
+		} else if graphql.Hash(query) != persisted.SHA256Hash {
+			status, _code := http.StatusBadRequest, gwcode.InvalidGraphQLQuery
+			msg := "sha256 hash of query is not matched with hash of persisted query"
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "errors": []gin.H{{"message": msg}}})
+			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+			return
+		}
+	}
+	if query == "" {
+		status, _code := http.StatusBadRequest, gwcode.InvalidGraphQLQuery
+		msg := "query or hash of persisted query must be set in request"
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "errors": []gin.H{{"message": msg}}})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+		return
+	}
+	if h.config.GraphQLPersistedOnly && !h.graphQLPersistedQueries.Allowed(query) {
+		status, _code := http.StatusForbidden, gwcode.GraphQLQueryNotAllowed
+		msg := "only query registered in persisted query allowlist can be requested"
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "errors": []gin.H{{"message": msg}}})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Warn()
+		return
+	}
+
+	limit := graphql.Limit{MaxDepth: h.config.GraphQLMaxDepth, MaxComplexity: h.config.GraphQLMaxComplexity}
+	prepared, err := graphql.Prepare(h.graphQLSchema, query, receivedReq.OperationName, receivedReq.Variables, limit)
+	if err != nil {
+		status, _code := http.StatusBadRequest, gwcode.InvalidGraphQLQuery
+		if _, ok := err.(graphql.ErrLimit); ok {
+			_code = gwcode.GraphQLQueryLimitExceeded
+		}
+		msg := err.Error()
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "errors": []gin.H{{"message": msg}}})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+		return
+	}
+
+	reqCtx := &graphQLRequestContext{reqID: reqID, topSpan: topSpan, claims: uuidClaims}
+	reqCtx.students = graphql.NewLoader(h.loadStudentInforms, graphQLLoaderWait, graphQLLoaderMaxBatch)
+	reqCtx.clubs = graphql.NewLoader(h.loadClubInforms, graphQLLoaderWait, graphQLLoaderMaxBatch)
+	result := prepared.Execute(context.WithValue(c.Request.Context(), graphQLContextKey{}, reqCtx))
+
+	status, _code := http.StatusOK, 0
+	msg := "succeed to execute GraphQL query"
+	if len(result.Errors) != 0 {
+		msg = fmt.Sprintf("executed GraphQL query but some fields failed to be resolved, count: %d", len(result.Errors))
+	}
+	sendResp := gin.H{"status": status, "code": _code, "message": msg, "data": result.Data}
+	if len(result.Errors) != 0 {
+		sendResp["errors"] = result.Errors
+	}
+	envelope.JSON(c, status, sendResp)
+	if len(result.Errors) == 0 {
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+	} else {
+		errBytes, _ := redact.Marshal(result.Errors)
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "errors": string(errBytes), "request": string(reqBytes)}).Warn()
+	}
+	return
+}
+
+// batch function of student loader, calling GetStudentInformsWithUUIDs once for all student uuids in batch
+func (h *_default) loadStudentInforms(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	reqCtx := graphQLRequestContextFrom(ctx)
+	rpcReq := entity.GetStudentInformsWithUUIDsRequest{StudentUUIDs: keys}.GenerateGRPCRequest()
+	rpcReq.UUID = reqCtx.claims.UUID
+
+	var rpcResp *authproto.GetStudentInformsWithUUIDsResponse
+	if err := h.callService(ctx, topic.AuthServiceName, "GetStudentInformsWithUUIDs", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.authService.GetStudentInformsWithUUIDs(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+	}
+
+	students := make(map[string]interface{}, len(rpcResp.StudentInforms))
+	for _, studentInform := range rpcResp.StudentInforms {
+		students[studentInform.StudentUUID] = map[string]interface{}{
+			"studentUUID":   studentInform.StudentUUID,
+			"grade":         studentInform.Grade,
+			"group":         studentInform.Group,
+			"studentNumber": studentInform.StudentNumber,
+			"name":          studentInform.Name,
+			"phoneNumber":   studentInform.PhoneNumber,
+			"profileURI":    studentInform.ImageURI,
+		}
+	}
+	return students, nil
+}
+
+// batch function of club loader, calling GetClubInformsWithUUIDs once for all club uuids in batch
+func (h *_default) loadClubInforms(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	reqCtx := graphQLRequestContextFrom(ctx)
+	rpcReq := entity.GetClubInformsWithUUIDsRequest{ClubUUIDs: keys}.GenerateGRPCRequest()
+	rpcReq.UUID = reqCtx.claims.UUID
+
+	var rpcResp *clubproto.GetClubInformsWithUUIDsResponse
+	if err := h.callService(ctx, topic.ClubServiceName, "GetClubInformsWithUUIDs", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.clubService.GetClubInformsWithUUIDs(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+	}
+
+	clubs := make(map[string]interface{}, len(rpcResp.Informs))
+	for _, clubInform := range rpcResp.Informs {
+		club := map[string]interface{}{
+			"clubUUID":     clubInform.ClubUUID,
+			"leaderUUID":   clubInform.LeaderUUID,
+			"memberUUIDs":  clubInform.MemberUUIDs,
+			"name":         clubInform.Name,
+			"location":     clubInform.Location,
+			"field":        clubInform.Field,
+			"link":         clubInform.Link,
+			"introduction": clubInform.Introduction,
+			"clubConcept":  clubInform.ClubConcept,
+			"logoURI":      clubInform.LogoURI,
+		}
+		if intField, err := strconv.Atoi(clubInform.Floor); err == nil {
+			club["floor"] = intField
+		} else {
+			club["floor"] = clubInform.Floor
+		}
+		clubs[clubInform.ClubUUID] = club
+	}
+	return clubs, nil
+}
+
+// return resolver loading value of key in source (or argument if source is nil) with loader selected by selectLoader
+func loadWith(selectLoader func(*graphQLRequestContext) *graphql.Loader, key string) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		var uuid string
+		if source, ok := p.Source.(map[string]interface{}); ok {
+			uuid, _ = source[key].(string)
+		} else if uuid = graphql.StringArg(p.Args, key); uuid == "" {
+			return nil, fmt.Errorf("argument %s is required", key)
+		}
+		if uuid == "" {
+			return nil, nil
+		}
+		return selectLoader(graphQLRequestContextFrom(p.Context)).Load(p.Context, uuid)
+	}
+}
+
+// return resolver loading values of keys in source (or argument if source is nil) with loader selected by selectLoader
+func loadManyWith(selectLoader func(*graphQLRequestContext) *graphql.Loader, key string) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		var uuids []string
+		if source, ok := p.Source.(map[string]interface{}); ok {
+			uuids, _ = source[key].([]string)
+		} else {
+			uuids = graphql.StringListArg(p.Args, key)
+		}
+		values, errs := selectLoader(graphQLRequestContextFrom(p.Context)).LoadMany(p.Context, uuids)
+		for _, err := range errs {
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+}
+
+func studentLoader(reqCtx *graphQLRequestContext) *graphql.Loader { return reqCtx.students }
+func clubLoader(reqCtx *graphQLRequestContext) *graphql.Loader    { return reqCtx.clubs }
+
+// return list size of field from count argument (or length of list argument) to calculate complexity of query
+func countArgSize(name string, def int) func(args map[string]interface{}) int {
+	return func(args map[string]interface{}) int {
+		if list, ok := args[name].([]interface{}); ok {
+			return len(list)
+		}
+		return graphql.IntArg(args, name, def)
+	}
+}
+
+// build GraphQL schema which is used in all GraphQL requests
+func (h *_default) newGraphQLSchema() *graphql.Schema {
+	student := &graphql.Object{Name: "Student"}
+	club := &graphql.Object{Name: "Club"}
+	recruitMember := &graphql.Object{Name: "RecruitMember"}
+	recruitment := &graphql.Object{Name: "Recruitment"}
+	outing := &graphql.Object{Name: "Outing"}
+	announcement := &graphql.Object{Name: "Announcement"}
+	schedule := &graphql.Object{Name: "Schedule"}
+
+	student.Fields = map[string]*graphql.Field{
+		"studentUUID": {}, "grade": {}, "group": {}, "studentNumber": {}, "name": {}, "phoneNumber": {}, "profileURI": {},
+	}
+	club.Fields = map[string]*graphql.Field{
+		"clubUUID": {}, "leaderUUID": {}, "memberUUIDs": {List: true}, "name": {}, "location": {}, "floor": {}, "field": {},
+		"link": {}, "introduction": {}, "clubConcept": {}, "logoURI": {},
+		"leader":      {Type: student, Resolve: loadWith(studentLoader, "leaderUUID")},
+		"members":     {Type: student, List: true, Resolve: loadManyWith(studentLoader, "memberUUIDs")},
+		"recruitment": {Type: recruitment, Resolve: h.resolveClubRecruitment},
+	}
+	recruitMember.Fields = map[string]*graphql.Field{
+		"field": {}, "grade": {}, "number": {},
+	}
+	recruitment.Fields = map[string]*graphql.Field{
+		"recruitmentUUID": {}, "clubUUID": {}, "recruitConcept": {}, "startPeriod": {}, "endPeriod": {},
+		"recruitMembers": {Type: recruitMember, List: true, ListSize: func(map[string]interface{}) int { return 3 }},
+		"club":           {Type: club, Resolve: loadWith(clubLoader, "clubUUID")},
+	}
+	outing.Fields = map[string]*graphql.Field{
+		"outingUUID": {}, "place": {}, "reason": {}, "startTime": {}, "endTime": {}, "outingSituation": {}, "outingStatus": {},
+		"arrivalTime": {}, "studentUUID": {},
+		"student": {Type: student, Resolve: loadWith(studentLoader, "studentUUID")},
+	}
+	announcement.Fields = map[string]*graphql.Field{
+		"announcementUUID": {}, "number": {}, "title": {}, "date": {}, "views": {}, "writerName": {}, "isChecked": {},
+	}
+	schedule.Fields = map[string]*graphql.Field{
+		"scheduleUUID": {}, "startDate": {}, "endDate": {}, "detail": {},
+	}
+
+	query := &graphql.Object{Name: "Query", Fields: map[string]*graphql.Field{
+		"student":        {Type: student, Resolve: loadWith(studentLoader, "uuid")},
+		"students":       {Type: student, List: true, Resolve: loadManyWith(studentLoader, "uuids"), ListSize: countArgSize("uuids", 0)},
+		"club":           {Type: club, Resolve: loadWith(clubLoader, "uuid")},
+		"clubs":          {Type: club, List: true, Resolve: h.resolveClubs, ListSize: countArgSize("count", 10)},
+		"recruitment":    {Type: recruitment, Resolve: h.resolveRecruitment},
+		"outing":         {Type: outing, Resolve: h.resolveOuting},
+		"studentOutings": {Type: outing, List: true, Resolve: h.resolveStudentOutings, ListSize: countArgSize("count", 10)},
+		"announcements":  {Type: announcement, List: true, Resolve: h.resolveAnnouncements, ListSize: countArgSize("count", 10)},
+		"schedules":      {Type: schedule, List: true, Resolve: h.resolveSchedules},
+	}}
+	return &graphql.Schema{Query: query}
+}
+
+// clubs(start: Int, count: Int, field: String, name: String): [Club]
+func (h *_default) resolveClubs(p graphql.ResolveParams) (interface{}, error) {
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := entity.GetClubsSortByUpdateTimeRequest{
+		Start: graphql.IntArg(p.Args, "start", 0),
+		Count: graphql.IntArg(p.Args, "count", 10),
+		Field: graphql.StringArg(p.Args, "field"),
+		Name:  graphql.StringArg(p.Args, "name"),
+	}.GenerateGRPCRequest()
+	rpcReq.UUID = reqCtx.claims.UUID
+
+	var rpcResp *clubproto.GetClubsSortByUpdateTimeResponse
+	if err := h.callService(p.Context, topic.ClubServiceName, "GetClubsSortByUpdateTime", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.clubService.GetClubsSortByUpdateTime(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+	}
+
+	clubs := make([]map[string]interface{}, len(rpcResp.Informs))
+	for index, clubInform := range rpcResp.Informs {
+		club := map[string]interface{}{
+			"clubUUID":     clubInform.ClubUUID,
+			"leaderUUID":   clubInform.LeaderUUID,
+			"memberUUIDs":  clubInform.MemberUUIDs,
+			"name":         clubInform.Name,
+			"location":     clubInform.Location,
+			"field":        clubInform.Field,
+			"link":         clubInform.Link,
+			"introduction": clubInform.Introduction,
+			"clubConcept":  clubInform.ClubConcept,
+			"logoURI":      clubInform.LogoURI,
+		}
+		if intField, err := strconv.Atoi(clubInform.Floor); err == nil {
+			club["floor"] = intField
+		} else {
+			club["floor"] = clubInform.Floor
+		}
+		clubs[index] = club
+	}
+	return clubs, nil
+}
+
+// recruitment(uuid: String!): Recruitment
+func (h *_default) resolveRecruitment(p graphql.ResolveParams) (interface{}, error) {
+	uuid := graphql.StringArg(p.Args, "uuid")
+	if uuid == "" {
+		return nil, fmt.Errorf("argument uuid is required")
+	}
+	return h.getGraphQLRecruitment(p.Context, uuid)
+}
+
+// Club.recruitment: Recruitment, null if club doesn't have recruitment in progress
+func (h *_default) resolveClubRecruitment(p graphql.ResolveParams) (interface{}, error) {
+	source, _ := p.Source.(map[string]interface{})
+	clubUUID, _ := source["clubUUID"].(string)
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := new(clubproto.GetRecruitmentUUIDWithClubUUIDRequest)
+	rpcReq.UUID = reqCtx.claims.UUID
+	rpcReq.ClubUUID = clubUUID
+
+	var rpcResp *clubproto.GetRecruitmentUUIDWithClubUUIDResponse
+	if err := h.callService(p.Context, topic.ClubServiceName, "GetRecruitmentUUIDWithClubUUID", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.clubService.GetRecruitmentUUIDWithClubUUID(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	switch rpcResp.Status {
+	case http.StatusOK:
+		return h.getGraphQLRecruitment(p.Context, rpcResp.RecruitmentUUID)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+	}
+}
+
+func (h *_default) getGraphQLRecruitment(ctx context.Context, recruitmentUUID string) (interface{}, error) {
+	reqCtx := graphQLRequestContextFrom(ctx)
+	rpcReq := new(clubproto.GetRecruitmentInformWithUUIDRequest)
+	rpcReq.UUID = reqCtx.claims.UUID
+	rpcReq.RecruitmentUUID = recruitmentUUID
+
+	var rpcResp *clubproto.GetRecruitmentInformWithUUIDResponse
+	if err := h.callService(ctx, topic.ClubServiceName, "GetRecruitmentInformWithUUID", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.clubService.GetRecruitmentInformWithUUID(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	switch rpcResp.Status {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+	}
+
+	members := make([]map[string]interface{}, len(rpcResp.RecruitMembers))
+	for index, member := range rpcResp.RecruitMembers {
+		members[index] = map[string]interface{}{
+			"field":  member.Field,
+			"grade":  member.Grade,
+			"number": member.Number,
+		}
+	}
+	return map[string]interface{}{
+		"recruitmentUUID": rpcResp.RecruitmentUUID,
+		"clubUUID":        rpcResp.ClubUUID,
+		"recruitConcept":  rpcResp.RecruitConcept,
+		"recruitMembers":  members,
+		"startPeriod":     rpcResp.StartPeriod,
+		"endPeriod":       rpcResp.EndPeriod,
+	}, nil
+}
+
+// outing(uuid: String!): Outing
+func (h *_default) resolveOuting(p graphql.ResolveParams) (interface{}, error) {
+	uuid := graphql.StringArg(p.Args, "uuid")
+	if uuid == "" {
+		return nil, fmt.Errorf("argument uuid is required")
+	}
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := new(outingproto.GetOutingInformRequest)
+	rpcReq.Uuid = reqCtx.claims.UUID
+	rpcReq.OutingId = uuid
+
+	var rpcResp *outingproto.GetOutingInformResponse
+	if err := h.callService(p.Context, topic.OutingServiceName, "GetOutingInform", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.outingService.GetOutingInform(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	switch rpcResp.Status {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Msg}
+	}
+
+	return map[string]interface{}{
+		"outingUUID":      rpcResp.OutingId,
+		"place":           rpcResp.Place,
+		"reason":          rpcResp.Reason,
+		"startTime":       rpcResp.StartTime,
+		"endTime":         rpcResp.EndTime,
+		"outingSituation": rpcResp.OutingSituation,
+		"outingStatus":    rpcResp.OutingStatus,
+		"studentUUID":     rpcResp.StudentUuid,
+	}, nil
+}
+
+// studentOutings(studentUUID: String!, start: Int, count: Int): [Outing]
+func (h *_default) resolveStudentOutings(p graphql.ResolveParams) (interface{}, error) {
+	studentUUID := graphql.StringArg(p.Args, "studentUUID")
+	if studentUUID == "" {
+		return nil, fmt.Errorf("argument studentUUID is required")
+	}
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := entity.GetStudentOutingsRequest{
+		Start: int32(graphql.IntArg(p.Args, "start", 0)),
+		Count: int32(graphql.IntArg(p.Args, "count", 10)),
+	}.GenerateGRPCRequest()
+	rpcReq.Uuid = reqCtx.claims.UUID
+	rpcReq.StudentId = studentUUID
+
+	var rpcResp *outingproto.GetStudentOutingsResponse
+	if err := h.callService(p.Context, topic.OutingServiceName, "GetStudentOutings", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.outingService.GetStudentOutings(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Msg}
+	}
+
+	outings := make([]map[string]interface{}, len(rpcResp.Outing))
+	for index, outing := range rpcResp.Outing {
+		outings[index] = map[string]interface{}{
+			"outingUUID":      outing.OutingId,
+			"place":           outing.Place,
+			"reason":          outing.Reason,
+			"startTime":       outing.StartTime,
+			"endTime":         outing.EndTime,
+			"outingSituation": outing.Situation,
+			"outingStatus":    outing.Status,
+			"arrivalTime":     outing.ArrivalTime,
+			"studentUUID":     studentUUID,
+		}
+	}
+	return outings, nil
+}
+
+// announcements(type: String!, start: Int, count: Int): [Announcement]
+func (h *_default) resolveAnnouncements(p graphql.ResolveParams) (interface{}, error) {
+	announcementType := graphql.StringArg(p.Args, "type")
+	if announcementType == "" {
+		return nil, fmt.Errorf("argument type is required")
+	}
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := entity.GetAnnouncementsRequest{
+		Start: int32(graphql.IntArg(p.Args, "start", 0)),
+		Count: int32(graphql.IntArg(p.Args, "count", 10)),
+	}.GenerateGRPCRequest()
+	rpcReq.Uuid = reqCtx.claims.UUID
+	rpcReq.Type = announcementType
+
+	var rpcResp *announcementproto.GetAnnouncementsResponse
+	if err := h.callService(p.Context, topic.AnnouncementServiceName, "GetAnnouncements", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.announcementService.GetAnnouncements(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Msg}
+	}
+
+	announcements := make([]map[string]interface{}, len(rpcResp.Announcement))
+	for index, announcement := range rpcResp.Announcement {
+		announcements[index] = map[string]interface{}{
+			"announcementUUID": announcement.AnnouncementId,
+			"number":           announcement.Number,
+			"title":            announcement.Title,
+			"date":             announcement.Date,
+			"views":            announcement.Views,
+			"writerName":       announcement.WriterName,
+			"isChecked":        announcement.IsChecked,
+		}
+	}
+	return announcements, nil
+}
+
+// schedules(year: Int!, month: Int!): [Schedule]
+func (h *_default) resolveSchedules(p graphql.ResolveParams) (interface{}, error) {
+	year, month := graphql.IntArg(p.Args, "year", 0), graphql.IntArg(p.Args, "month", 0)
+	if year <= 0 || month < 1 || month > 12 {
+		return nil, fmt.Errorf("argument year & month(1~12) are required")
+	}
+	reqCtx := graphQLRequestContextFrom(p.Context)
+	rpcReq := entity.GetScheduleRequest{Year: int32(year), Month: int32(month)}.GenerateGRPCRequest()
+	rpcReq.Uuid = reqCtx.claims.UUID
+
+	var rpcResp *scheduleproto.GetScheduleResponse
+	if err := h.callService(p.Context, topic.ScheduleServiceName, "GetSchedule", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			rpcResp, rpcErr = h.scheduleService.GetSchedule(ctx, rpcReq, callOpts...)
+			return rpcResp, rpcErr
+		}); err != nil {
+		return nil, err
+	}
+	if rpcResp.Status != http.StatusOK {
+		return nil, &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Msg}
+	}
+
+	schedules := make([]map[string]interface{}, len(rpcResp.Schedule))
+	for index, schedule := range rpcResp.Schedule {
+		schedules[index] = map[string]interface{}{
+			"scheduleUUID": schedule.ScheduleUUID,
+			"startDate":    schedule.StartDate,
+			"endDate":      schedule.EndDate,
+			"detail":       schedule.Detail,
+		}
+	}
+	return schedules, nil
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"gateway/entity"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
 	"github.com/gin-gonic/gin"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
@@ -48,7 +50,7 @@ func (h *_default) GetPlaceWithNaverOpenAPI(c *gin.Context) {
 
 	if limited {
 		msg := "you can use the API only once every 5 seconds, please wait"
-		c.JSON(http.StatusLocked, gin.H{"status": http.StatusLocked, "code": 0, "message": msg})
+		envelope.JSON(c, http.StatusLocked, gin.H{"status": http.StatusLocked, "code": 0, "message": msg})
 		entry.WithFields(logrus.Fields{"status": http.StatusLocked, "code": 0, "message": msg}).Info()
 		return
 	}
@@ -56,13 +58,13 @@ func (h *_default) GetPlaceWithNaverOpenAPI(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetPlaceWithNaverOpenAPIRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
 	openApiSpan := h.tracer.StartSpan("GetPlaceWithNaverOpenAPI", opentracing.ChildOf(topSpan.Context()))
 	openApiUri := fmt.Sprintf("%s?start=%d&display=%d&sort=%s&query=%s", NaverOpenApiURI, 1, 5, "comment", url.QueryEscape(receivedReq.Keyword))
 	req, _ := http.NewRequest("GET", openApiUri, nil)
-	req.Header.Set("X-Naver-Client-Id", naverClientID)
-	req.Header.Set("X-Naver-Client-Secret", naverClientSecret)
+	req.Header.Set("X-Naver-Client-Id", h.config.NaverClientID)
+	req.Header.Set("X-Naver-Client-Secret", h.config.NaverClientSecret)
 	resp, err := h.client.Do(req)
 	openApiSpan.SetTag("X-Request-Id", reqID).LogFields(log.String("user_uuid", uuidClaims.UUID),
 		log.String("uri", openApiUri), log.Error(err), log.Object("response", resp))
@@ -71,7 +73,7 @@ func (h *_default) GetPlaceWithNaverOpenAPI(c *gin.Context) {
 	if err != nil {
 		status := http.StatusInternalServerError
 		msg := "unexpected error occurs while sending request to naver open api"
-		c.JSON(status, gin.H{"status": status, "code": 0, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": 0, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": 0, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -80,14 +82,14 @@ func (h *_default) GetPlaceWithNaverOpenAPI(c *gin.Context) {
 	if err := json.NewDecoder(resp.Body).Decode(decodedResp); err != nil {
 		status := http.StatusInternalServerError
 		msg := "unexpected error occurs while decoding response body from naver open api"
-		c.JSON(status, gin.H{"status": status, "code": 0, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": 0, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": 0, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("unexpected error occurs while decoding response body from naver open api, reason: %s", decodedResp.ErrorMessage)
-		c.JSON(resp.StatusCode, gin.H{"status": resp.StatusCode, "code": 0, "message": msg})
+		envelope.JSON(c, resp.StatusCode, gin.H{"status": resp.StatusCode, "code": 0, "message": msg})
 		entry.WithFields(logrus.Fields{"status": resp.StatusCode, "code": decodedResp.ErrorCode, "message": msg, "request": string(reqBytes)}).Warn()
 		return
 	}
@@ -95,8 +97,8 @@ func (h *_default) GetPlaceWithNaverOpenAPI(c *gin.Context) {
 	decodedResp.ErrorMessage = "succeed to get place list from naver open api"
 	sendResp := gin.H{"status": resp.StatusCode, "code": 0, "message": decodedResp.ErrorMessage, "item": decodedResp.Items,
 		"lastBuildDate": decodedResp.LastBuildDate, "total": decodedResp.Total, "start": decodedResp.Start, "display": decodedResp.Display}
-	c.JSON(resp.StatusCode, sendResp)
-	respBytes, _ := json.Marshal(sendResp)
+	envelope.JSON(c, resp.StatusCode, sendResp)
+	respBytes, _ := redact.Marshal(sendResp)
 	entry.WithFields(logrus.Fields{"status": resp.StatusCode, "code": decodedResp.ErrorCode, "message": decodedResp.ErrorMessage,
 		"response": string(respBytes), "request": string(reqBytes), "date": time.Now().Format("2006-01-02")}).Info()
 
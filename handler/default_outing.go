@@ -1,12 +1,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	outingproto "gateway/proto/golang/outing"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/eapache/go-resiliency/breaker"
@@ -17,7 +18,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"time"
 )
@@ -41,12 +41,12 @@ func (h *_default) CreateOuting(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.CreateOutingRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -61,14 +61,14 @@ func (h *_default) CreateOuting(c *gin.Context) {
 	var rpcResp *outingproto.CreateOutingResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("CreateOuting", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.CreateOuting(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -86,7 +86,7 @@ func (h *_default) CreateOuting(c *gin.Context) {
 			msg = fmt.Sprintf("CreateOuting returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -101,7 +101,7 @@ func (h *_default) CreateOuting(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("CreateOuting returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -111,14 +111,14 @@ func (h *_default) CreateOuting(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		msg := "succeed to create new outing"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "outing_uuid": rpcResp.OutingId}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -144,12 +144,12 @@ func (h *_default) GetStudentOutings(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetStudentOutingsRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -164,15 +164,15 @@ func (h *_default) GetStudentOutings(c *gin.Context) {
 	var rpcResp *outingproto.GetStudentOutingsResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("GetStudentOutings", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.StudentId = c.Param("student_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.GetStudentOutings(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -190,7 +190,7 @@ func (h *_default) GetStudentOutings(c *gin.Context) {
 			msg = fmt.Sprintf("GetStudentOutings returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -205,7 +205,7 @@ func (h *_default) GetStudentOutings(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetStudentOutings returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -228,14 +228,14 @@ func (h *_default) GetStudentOutings(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "outings": outings}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -258,10 +258,10 @@ func (h *_default) GetOutingInform(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -276,15 +276,15 @@ func (h *_default) GetOutingInform(c *gin.Context) {
 	var rpcResp *outingproto.GetOutingInformResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("GetOutingInform", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := new(outingproto.GetOutingInformRequest)
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.OutingId = c.Param("outing_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.GetOutingInform(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -302,7 +302,7 @@ func (h *_default) GetOutingInform(c *gin.Context) {
 			msg = fmt.Sprintf("GetOutingInform returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -317,7 +317,7 @@ func (h *_default) GetOutingInform(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetOutingInform returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -339,14 +339,14 @@ func (h *_default) GetOutingInform(c *gin.Context) {
 			"outing_status":    rpcResp.OutingStatus,
 			"student_uuid":     rpcResp.StudentUuid,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -369,10 +369,10 @@ func (h *_default) GetCardAboutOuting(c *gin.Context) {
 	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 	entry = entry.WithField("user_uuid", uuidClaims.UUID)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -387,15 +387,15 @@ func (h *_default) GetCardAboutOuting(c *gin.Context) {
 	var rpcResp *outingproto.GetCardAboutOutingResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("GetCardAboutOuting", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := new(outingproto.GetCardAboutOutingRequest)
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.OutingId = c.Param("outing_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.GetCardAboutOuting(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -413,7 +413,7 @@ func (h *_default) GetCardAboutOuting(c *gin.Context) {
 			msg = fmt.Sprintf("GetCardAboutOuting returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -428,7 +428,7 @@ func (h *_default) GetCardAboutOuting(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetCardAboutOuting returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -452,14 +452,14 @@ func (h *_default) GetCardAboutOuting(c *gin.Context) {
 			"profile_uri":   rpcResp.ProfileImageUri,
 			"reason":        rpcResp.Reason,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -477,13 +477,25 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 	inAdvanceEntry, _ := c.Get("RequestLogEntry")
 	entry, _ := inAdvanceEntry.(*logrus.Entry)
 
-	// logic handling Unauthorized
+	// logic handling Unauthorized, parent action is authorized with signed token in link instead of access token (change in v.1.0.6)
 	var uuidClaims jwtutil.UUIDClaims
-	if ok, claims, _code, msg := h.checkIfAuthenticated(c); ok {
+	var actionClaims *jwtutil.ParentActionClaims
+	var actionToken parentActionToken
+	if action := c.Param("action"); action == "parent-approve" || action == "parent-reject" {
+		claims, token, status, _code, msg := h.consumeParentActionToken(c)
+		if claims == nil {
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
+			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Warn()
+			return
+		}
+		actionClaims, actionToken = claims, token
+		entry = entry.WithField("token_id", actionClaims.Id)
+	} else if ok, claims, _code, msg := h.checkIfAuthenticated(c); ok {
 		uuidClaims = claims
 		entry = entry.WithField("user_uuid", uuidClaims.UUID)
-	} else if action := c.Param("action"); !(action == "parent-approve" || action == "parent-reject") {
-		c.JSON(http.StatusUnauthorized, gin.H{"status": http.StatusUnauthorized, "code": _code, "message": msg})
+		c.Set("Claims", uuidClaims) // set claims as Authenticator do for audit recorder (add in v.1.0.6)
+	} else {
+		envelope.JSON(c, http.StatusUnauthorized, gin.H{"status": http.StatusUnauthorized, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": http.StatusUnauthorized, "code": _code, "message": msg}).Info()
 		return
 	}
@@ -506,15 +518,18 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 		methodName = "RejectOutingByOCode"
 	default:
 		msg := "that action in uri is not supported"
-		c.JSON(http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": msg})
+		envelope.JSON(c, http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": msg})
 		entry.WithFields(logrus.Fields{"status": http.StatusNotFound, "code": 0, "message": msg}).Info()
 		return
 	}
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
+		if actionClaims != nil {
+			h.restoreParentActionToken(actionClaims, actionToken)
+		}
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -531,9 +546,9 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 		var rpcResp *outingproto.GoOutResponse
 		err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 			outingSrvSpan := h.tracer.StartSpan(methodName, opentracing.ChildOf(topSpan.Context()))
-			ctxForReq := context.Background()
+			ctxForReq := rpcContext(c)
 			ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-			ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+			ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 			rpcReq := new(outingproto.GoOutRequest)
 			rpcReq.Uuid = uuidClaims.UUID
 			rpcReq.OutingId = c.Param("outing_uuid")
@@ -544,7 +559,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			case "end":
 				rpcResp, rpcErr = h.outingService.FinishGoOut(ctxForReq, rpcReq, callOpts...)
 			}
-			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 			outingSrvSpan.Finish()
 			return
 		})
@@ -562,7 +577,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				msg = fmt.Sprintf("%s returns unexpected micro error, code: %d, detail: %s", methodName, rpcErr.Code, rpcErr.Detail)
 				status, _code = http.StatusInternalServerError, 0
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		default:
@@ -577,7 +592,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				status, _code = http.StatusInternalServerError, 0
 				msg = fmt.Sprintf("%s returns unexpected type of error, err: %s", methodName, rpcErr.Error())
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		}
@@ -587,14 +602,14 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			status, _code := http.StatusOK, 0
 			msg := "succeed to take action to outing"
 			sendResp := gin.H{"status": status, "code": _code, "message": msg}
-			c.JSON(status, sendResp)
-			respBytes, _ := json.Marshal(sendResp)
+			envelope.JSON(c, status, sendResp)
+			respBytes, _ := redact.Marshal(sendResp)
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 		case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 		default:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 		}
 
@@ -604,9 +619,9 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 		var rpcResp *outingproto.ConfirmOutingResponse
 		err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 			outingSrvSpan := h.tracer.StartSpan(methodName, opentracing.ChildOf(topSpan.Context()))
-			ctxForReq := context.Background()
+			ctxForReq := rpcContext(c)
 			ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-			ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+			ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 			rpcReq := new(outingproto.ConfirmOutingRequest)
 			rpcReq.Uuid = uuidClaims.UUID
 			rpcReq.OutingId = c.Param("outing_uuid")
@@ -619,7 +634,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			case "certify":
 				rpcResp, rpcErr = h.outingService.CertifyOuting(ctxForReq, rpcReq, callOpts...)
 			}
-			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 			outingSrvSpan.Finish()
 			return
 		})
@@ -637,7 +652,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				msg = fmt.Sprintf("%s returns unexpected micro error, code: %d, detail: %s", methodName, rpcErr.Code, rpcErr.Detail)
 				status, _code = http.StatusInternalServerError, 0
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		default:
@@ -652,7 +667,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				status, _code = http.StatusInternalServerError, 0
 				msg = fmt.Sprintf("%s returns unexpected type of error, err: %s", methodName, rpcErr.Error())
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		}
@@ -662,14 +677,14 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			status, _code := http.StatusOK, 0
 			msg := "succeed to take action to outing"
 			sendResp := gin.H{"status": status, "code": _code, "message": msg}
-			c.JSON(status, sendResp)
-			respBytes, _ := json.Marshal(sendResp)
+			envelope.JSON(c, status, sendResp)
+			respBytes, _ := redact.Marshal(sendResp)
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 		case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 		default:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 		}
 
@@ -679,11 +694,11 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 		var rpcResp *outingproto.ConfirmOutingByOCodeResponse
 		err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 			outingSrvSpan := h.tracer.StartSpan(methodName, opentracing.ChildOf(topSpan.Context()))
-			ctxForReq := context.Background()
+			ctxForReq := rpcContext(c)
 			ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-			ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+			ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 			rpcReq := new(outingproto.ConfirmOutingByOCodeRequest)
-			rpcReq.ConfirmCode = c.Request.URL.Query().Get("code")
+			rpcReq.ConfirmCode = actionToken.ConfirmCode
 			callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 			switch c.Param("action") {
 			case "parent-approve":
@@ -691,11 +706,16 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			case "parent-reject":
 				rpcResp, rpcErr = h.outingService.RejectOutingByOCode(ctxForReq, rpcReq, callOpts...)
 			}
-			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+			outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 			outingSrvSpan.Finish()
 			return
 		})
 
+		// restore consumed token so that parent can retry with the same link if failed by error not caused by parent
+		if err != nil || rpcResp.Status >= http.StatusInternalServerError {
+			h.restoreParentActionToken(actionClaims, actionToken)
+		}
+
 		switch rpcErr := err.(type) {
 		case nil:
 			break
@@ -709,7 +729,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				msg = fmt.Sprintf("%s returns unexpected micro error, code: %d, detail: %s", methodName, rpcErr.Code, rpcErr.Detail)
 				status, _code = http.StatusInternalServerError, 0
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		default:
@@ -724,7 +744,7 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 				status, _code = http.StatusInternalServerError, 0
 				msg = fmt.Sprintf("%s returns unexpected type of error, err: %s", methodName, rpcErr.Error())
 			}
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 			return
 		}
@@ -734,14 +754,14 @@ func (h *_default) TakeActionInOuting(c *gin.Context) {
 			status, _code := http.StatusOK, 0
 			msg := "succeed to take action to outing"
 			sendResp := gin.H{"status": status, "code": _code, "message": msg}
-			c.JSON(status, sendResp)
-			respBytes, _ := json.Marshal(sendResp)
+			envelope.JSON(c, status, sendResp)
+			respBytes, _ := redact.Marshal(sendResp)
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 		case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 		default:
-			c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+			envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 			entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 		}
 
@@ -768,12 +788,12 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.GetOutingWithFilterRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -788,14 +808,14 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 	var rpcResp *outingproto.OutingResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("GetOutingWithFilter", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.GetOutingWithFilter(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -813,7 +833,7 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 			msg = fmt.Sprintf("GetOutingWithFilter returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -828,7 +848,7 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetOutingWithFilter returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -841,6 +861,7 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 		for index, outing := range rpcResp.Outing {
 			outings[index] = map[string]interface{}{
 				"outing_uuid":      outing.OutingId,
+				"student_uuid":     outing.StudentUuid, // add in v.1.0.6
 				"place":            outing.Place,
 				"reason":           outing.Reason,
 				"start_time":       outing.StartTime,
@@ -856,14 +877,14 @@ func (h *_default) GetOutingWithFilter(c *gin.Context) {
 			}
 		}
 		sendResp := gin.H{"status": status, "code": _code, "message": msg, "outings": outings}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -881,10 +902,10 @@ func (h *_default) GetOutingByOCode(c *gin.Context) {
 	inAdvanceEntry, _ := c.Get("RequestLogEntry")
 	entry, _ := inAdvanceEntry.(*logrus.Entry)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -899,14 +920,14 @@ func (h *_default) GetOutingByOCode(c *gin.Context) {
 	var rpcResp *outingproto.GetOutingByOCodeResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("GetOutingByOCode", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := new(outingproto.GetOutingByOCodeRequest)
 		rpcReq.ConfirmCode = c.Param("OCode")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.GetOutingByOCode(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -924,7 +945,7 @@ func (h *_default) GetOutingByOCode(c *gin.Context) {
 			msg = fmt.Sprintf("GetOutingByOCode returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	default:
@@ -939,7 +960,7 @@ func (h *_default) GetOutingByOCode(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("GetOutingByOCode returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Error()
 		return
 	}
@@ -959,14 +980,14 @@ func (h *_default) GetOutingByOCode(c *gin.Context) {
 			"end_time":         rpcResp.EndTime,
 			"outing_situation": rpcResp.Situation,
 		}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg}).Info()
 	}
 
@@ -992,12 +1013,12 @@ func (h *_default) ModifyOuting(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.ModifyOutingRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.OutingServiceName)
+	selectedNode, err := h.selectNode(c, topic.OutingServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -1012,15 +1033,15 @@ func (h *_default) ModifyOuting(c *gin.Context) {
 	var rpcResp *outingproto.ConfirmOutingResponse
 	err = h.breakers[selectedNode.Id].Run(func() (rpcErr error) {
 		outingSrvSpan := h.tracer.StartSpan("ModifyOuting", opentracing.ChildOf(topSpan.Context()))
-		ctxForReq := context.Background()
+		ctxForReq := rpcContext(c)
 		ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-		ctxForReq = metadata.Set(ctxForReq, "Span-Context", outingSrvSpan.Context().(jaeger.SpanContext).String())
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, outingSrvSpan.Context())
 		rpcReq := receivedReq.GenerateGRPCRequest()
 		rpcReq.Uuid = uuidClaims.UUID
 		rpcReq.OutingId = c.Param("outing_uuid")
 		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 		rpcResp, rpcErr = h.outingService.ModifyOuting(ctxForReq, rpcReq, callOpts...)
-		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+		outingSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 		outingSrvSpan.Finish()
 		return
 	})
@@ -1038,7 +1059,7 @@ func (h *_default) ModifyOuting(c *gin.Context) {
 			msg = fmt.Sprintf("ModifyOuting returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
@@ -1053,7 +1074,7 @@ func (h *_default) ModifyOuting(c *gin.Context) {
 			status, _code = http.StatusInternalServerError, 0
 			msg = fmt.Sprintf("ModifyOuting returns unexpected type of error, err: %s", rpcErr.Error())
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -1063,14 +1084,14 @@ func (h *_default) ModifyOuting(c *gin.Context) {
 		status, _code := http.StatusOK, 0
 		msg := "succeed to modify outing"
 		sendResp := gin.H{"status": status, "code": _code, "message": msg}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Msg, "request": string(reqBytes)}).Info()
 	}
 
@@ -0,0 +1,214 @@
+// add file in v.1.0.6
+// default_outing_dashboard.go is file that declare handler joining filtered outings with informs of student & parent for teacher
+// auth service is called once for informs of all students, parents are loaded in one batch of loader with student uuids
+// (batch function calls GetParentWithStudentUUID per student concurrently, until auth service has batch rpc of parents)
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"gateway/entity"
+	"gateway/middleware"
+	authproto "gateway/proto/golang/auth"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	"gateway/tool/graphql"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	topic "gateway/utils/topic/golang"
+	"github.com/gin-gonic/gin"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// max number of GetParentWithStudentUUID running concurrently in one batch of parent loader
+const parentLoaderConcurrency = 5
+
+func (h *_default) GetOutingDashboardWithFilter(c *gin.Context) {
+	reqID := c.GetHeader("X-Request-Id")
+
+	// get top span from middleware
+	inAdvanceTopSpan, _ := c.Get("TopSpan")
+	topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.GetOutingDashboardWithFilterRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	// redis key is formatted in same way as RedisHandler middleware of GET /v1/outings/with-filter
+	filterReq := receivedReq.GetOutingWithFilterRequest
+	outingsKey, _ := middleware.FormatRedisKey(middleware.OutingsWithFilterKey, c, &filterReq, uuidClaims)
+	outingsResp := h.runDashboardSection(dashboardSubContext(c, entry, "outings"), dashboardSection{
+		name:     "outings",
+		handler:  h.GetOutingWithFilter,
+		request:  &filterReq,
+		redisKey: outingsKey,
+		timeout:  h.config.DashboardSectionTimeout,
+	})
+	if status := sectionStatus(outingsResp); status != http.StatusOK {
+		envelope.JSON(c, status, outingsResp)
+		fields := logrus.Fields{"status": status, "code": outingsResp["code"], "message": outingsResp["message"], "request": string(reqBytes)}
+		if status == http.StatusRequestTimeout || status >= http.StatusInternalServerError {
+			entry.WithFields(fields).Error()
+		} else {
+			entry.WithFields(fields).Info()
+		}
+		return
+	}
+	outings, _ := outingsResp["outings"].([]interface{})
+
+	// collect unique student uuids because one student can have several outings
+	var studentUUIDs []string
+	seen := map[string]bool{}
+	for _, outing := range outings {
+		inform, _ := outing.(map[string]interface{})
+		if sid, _ := inform["student_uuid"].(string); sid != "" && !seen[sid] {
+			seen[sid] = true
+			studentUUIDs = append(studentUUIDs, sid)
+		}
+	}
+
+	students := map[string]interface{}{}
+	parents := map[string]interface{}{}
+	var failed []string
+	if len(studentUUIDs) != 0 {
+		var studentsResp gin.H
+		var parentValues []interface{}
+		var parentErrs []error
+		wg := sync.WaitGroup{}
+
+		wg.Add(1)
+		go func(sub *gin.Context) {
+			defer wg.Done()
+			studentsResp = h.runDashboardSection(sub, dashboardSection{
+				name:    "students",
+				handler: h.GetStudentInformsWithUUIDs,
+				request: &entity.GetStudentInformsWithUUIDsRequest{StudentUUIDs: studentUUIDs},
+				timeout: h.config.DashboardSectionTimeout,
+			})
+		}(dashboardSubContext(c, entry, "students"))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reqCtx := &graphQLRequestContext{reqID: reqID, topSpan: topSpan, claims: uuidClaims}
+			reqCtx.parents = graphql.NewLoader(h.loadParentsWithStudentUUIDs, graphQLLoaderWait, graphQLLoaderMaxBatch)
+			ctx, cancel := context.WithTimeout(context.WithValue(c.Request.Context(), graphQLContextKey{}, reqCtx), h.config.DashboardSectionTimeout)
+			defer cancel()
+			parentValues, parentErrs = reqCtx.parents.LoadMany(ctx, studentUUIDs)
+		}()
+		wg.Wait()
+
+		if sectionStatus(studentsResp) == http.StatusOK {
+			informs, _ := studentsResp["students"].([]interface{})
+			for _, student := range informs {
+				inform, _ := student.(map[string]interface{})
+				if sid, _ := inform["student_uuid"].(string); sid != "" {
+					students[sid] = inform
+				}
+			}
+		} else {
+			failed = append(failed, "students")
+		}
+
+		// parent of student who doesn't have parent account is null, not failure
+		var parentErr error
+		for i, parent := range parentValues {
+			if parent != nil {
+				parents[studentUUIDs[i]] = parent
+			}
+			if parentErrs[i] != nil {
+				parentErr = parentErrs[i]
+			}
+		}
+		if parentErr != nil {
+			entry = entry.WithField("parents_error", parentErr.Error())
+			failed = append(failed, "parents")
+		}
+	}
+
+	joined := make([]map[string]interface{}, len(outings))
+	for i, outing := range outings {
+		inform, _ := outing.(map[string]interface{})
+		if inform == nil {
+			inform = map[string]interface{}{}
+		}
+		sid, _ := inform["student_uuid"].(string)
+		inform["student"], inform["parent"] = students[sid], parents[sid]
+		joined[i] = inform
+	}
+
+	status, _code := http.StatusOK, 0
+	msg := "succeed to get outing dashboard with filter"
+	if len(failed) != 0 {
+		_code = gwcode.PartialDashboardSections
+		msg = fmt.Sprintf("succeed to get outings but some informs joined with outings failed, sections: %s", strings.Join(failed, ", "))
+	}
+	sendResp := gin.H{"status": status, "code": _code, "message": msg, "outings": joined, "start": filterReq.Start, "count": filterReq.Count}
+	envelope.JSON(c, status, sendResp)
+	if len(failed) == 0 {
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
+	} else {
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Warn()
+	}
+	return
+}
+
+// batch function of parent loader, calling GetParentWithStudentUUID for each student uuid in batch concurrently
+// because auth service doesn't have batch rpc of parents, student without parent account is not in returned map
+// parents loaded successfully are returned with error of failed ones, so that loader sets both of them in each key
+func (h *_default) loadParentsWithStudentUUIDs(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	reqCtx := graphQLRequestContextFrom(ctx)
+	parents := make(map[string]interface{}, len(keys))
+	var lastErr error
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, parentLoaderConcurrency)
+
+	for _, key := range keys {
+		wg.Add(1)
+		go func(sid string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			rpcReq := new(authproto.GetParentWithStudentUUIDRequest)
+			rpcReq.UUID = reqCtx.claims.UUID
+			rpcReq.StudentUUID = sid
+			var rpcResp *authproto.GetParentWithStudentUUIDResponse
+			err := h.callService(ctx, topic.AuthServiceName, "GetParentWithStudentUUID", reqCtx.reqID, reqCtx.topSpan, rpcReq,
+				func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+					rpcResp, rpcErr = h.authService.GetParentWithStudentUUID(ctx, rpcReq, callOpts...)
+					return rpcResp, rpcErr
+				})
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case err != nil:
+				lastErr = err
+			case rpcResp.Status == http.StatusOK:
+				parents[sid] = gin.H{"parent_uuid": rpcResp.ParentUUID, "name": rpcResp.Name, "phone_number": rpcResp.PhoneNumber}
+			case rpcResp.Status == http.StatusRequestTimeout || rpcResp.Status >= http.StatusInternalServerError:
+				lastErr = &serviceError{status: int(rpcResp.Status), code: int(rpcResp.Code), message: rpcResp.Message}
+			}
+		}(key)
+	}
+	wg.Wait()
+	return parents, lastErr
+}
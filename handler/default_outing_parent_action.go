@@ -0,0 +1,322 @@
+// add file in v.1.0.6
+// default_outing_parent_action.go is file that declare handler issuing signed, expiring link with which parent approve
+// or reject outing & methods consuming token in that link only once
+// link opens confirmation page (GET), which sends action to TakeActionInOuting (POST) with same url when parent confirms
+// so link preview in SMS app doesn't consume token, both requests are exempted from SecurityFilter
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/entity"
+	authproto "gateway/proto/golang/auth"
+	outingproto "gateway/proto/golang/outing"
+	"gateway/tool/attempt"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	topic "gateway/utils/topic/golang"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// format of redis key saving confirm code of outing with issued parent action token id (jti) until the token is consumed or expired
+const parentActionTokenKeyFormat = "parent-action-token:%s"
+
+// teacher & admin can issue parent action links, teacher issues them when student applies outing
+var teacherUUIDRegex = regexp.MustCompile("^teacher-\\d{12}$")
+
+// value saved in redis key of parent action token, uuid of issuer is used to read parent of outing again when token is consumed
+type parentActionToken struct {
+	ConfirmCode string `json:"confirm_code"`
+	IssuerUUID  string `json:"issuer_uuid"`
+}
+
+func (h *_default) IssueParentActionLinks(c *gin.Context) {
+	reqID := c.GetHeader("X-Request-Id")
+
+	// get top span from middleware
+	inAdvanceTopSpan, _ := c.Get("TopSpan")
+	topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	// get token claim from middleware
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+	entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+	// get bound request entry from middleware
+	inAdvanceReq, _ := c.Get("Request")
+	receivedReq, _ := inAdvanceReq.(*entity.IssueParentActionLinksRequest)
+	reqBytes, _ := redact.Marshal(receivedReq)
+
+	if !adminUUIDRegex.MatchString(uuidClaims.UUID) && !teacherUUIDRegex.MatchString(uuidClaims.UUID) {
+		msg := "only teacher or admin can issue parent action links"
+		envelope.JSON(c, http.StatusForbidden, gin.H{"status": http.StatusForbidden, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusForbidden, "code": 0, "message": msg, "request": string(reqBytes)}).Warn()
+		return
+	}
+
+	// links are bound to parent of student who applied outing, parent is checked again when token is consumed
+	outingUUID := c.Param("outing_uuid")
+	parentUUID, err := h.getParentUUIDOfOuting(rpcContext(c), outingUUID, uuidClaims.UUID, reqID, topSpan)
+	if err != nil {
+		status, _code, msg := parentOfOutingErrStatus(err)
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
+		return
+	}
+	entry = entry.WithField("parent_uuid", parentUUID)
+
+	// approve & reject token share id so that parent can take only one of both actions
+	tokenID := uuid.New().String()
+	expiresAt := time.Now().Add(h.config.ParentActionLinkTTL)
+	links := map[string]string{}
+	for _, action := range []string{"parent-approve", "parent-reject"} {
+		token, err := jwtutil.GenerateParentActionString(jwtutil.ParentActionClaims{
+			OutingUUID: outingUUID,
+			ParentUUID: parentUUID,
+			Action:     action,
+			StandardClaims: jwt.StandardClaims{
+				Id:        tokenID,
+				ExpiresAt: expiresAt.Unix(),
+				IssuedAt:  time.Now().Unix(),
+			},
+		})
+		if err != nil {
+			msg := fmt.Sprintf("unable to sign parent action token, err: %v", err)
+			envelope.JSON(c, http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg})
+			entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": msg, "request": string(reqBytes)}).Error()
+			return
+		}
+		links[action] = fmt.Sprintf("%s/v1/outings/uuid/%s/actions/%s?token=%s",
+			strings.TrimSuffix(h.config.ParentActionLinkBaseURL, "/"), url.PathEscape(outingUUID), action, url.QueryEscape(token))
+	}
+
+	key := fmt.Sprintf(parentActionTokenKeyFormat, tokenID)
+	value, _ := json.Marshal(parentActionToken{ConfirmCode: receivedReq.ConfirmCode, IssuerUUID: uuidClaims.UUID})
+	if err := h.redisClient.Set(context.Background(), key, value, h.config.ParentActionLinkTTL).Err(); err != nil {
+		msg := fmt.Sprintf("unable to save parent action token in redis, err: %v", err)
+		envelope.JSON(c, http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": msg, "request": string(reqBytes)}).Error()
+		return
+	}
+
+	// links are not written in log because anyone having the link can take action
+	status, _code := http.StatusCreated, 0
+	msg := "succeed to issue parent action links"
+	envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "links": links, "expires_at": expiresAt.Unix()})
+	entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes),
+		"token_id": tokenID}).Info()
+	return
+}
+
+// confirmation page of parent action link, which posts same url with fetch if parent confirms action
+var parentActionPage = template.Must(template.New("parent-action").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p id="message">{{.Message}}</p>
+{{if .Confirmable}}<button id="confirm" type="button">{{.Title}}</button>
+<script>
+document.getElementById("confirm").addEventListener("click", function (e) {
+	e.target.disabled = true;
+	fetch(window.location.href, {method: "POST"})
+		.then(function (resp) { return resp.json(); })
+		.then(function (body) { document.getElementById("message").textContent = body.message; e.target.remove(); })
+		.catch(function () { document.getElementById("message").textContent = "{{.FailMessage}}"; e.target.disabled = false; });
+});
+</script>{{end}}
+</body>
+</html>`))
+
+// titles of confirmation page in each parent action
+var parentActionTitles = map[string]string{
+	"parent-approve": "외출 승인",
+	"parent-reject":  "외출 거절",
+}
+
+// respond confirmation page of parent action link, token is only verified & consumed when action is posted
+func (h *_default) GetParentActionPage(c *gin.Context) {
+	// get log entry from middleware
+	inAdvanceEntry, _ := c.Get("RequestLogEntry")
+	entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+	title, ok := parentActionTitles[c.Param("action")]
+	if !ok {
+		msg := "that action in uri is not supported in parent action link"
+		envelope.JSON(c, http.StatusNotFound, gin.H{"status": http.StatusNotFound, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusNotFound, "code": 0, "message": msg}).Info()
+		return
+	}
+
+	page := struct {
+		Title, Message, FailMessage string
+		Confirmable                 bool
+	}{Title: title, FailMessage: "요청을 처리하지 못했습니다. 잠시 후 다시 시도해주세요"}
+	status := http.StatusOK
+	claims, err := jwtutil.ParseParentActionClaimsFrom(c.Query("token"))
+	switch {
+	case err != nil || claims.OutingUUID != c.Param("outing_uuid") || claims.Action != c.Param("action"):
+		status, page.Message = http.StatusUnauthorized, "유효하지 않거나 만료된 링크입니다"
+	default:
+		page.Message, page.Confirmable = fmt.Sprintf("자녀의 외출 신청을 %s하시겠습니까?", strings.TrimPrefix(title, "외출 ")), true
+	}
+
+	buf := &bytes.Buffer{}
+	if err := parentActionPage.Execute(buf, page); err != nil {
+		msg := fmt.Sprintf("unable to render parent action page, err: %v", err)
+		envelope.JSON(c, http.StatusInternalServerError, gin.H{"status": http.StatusInternalServerError, "code": 0, "message": msg})
+		entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": msg}).Error()
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer") // token in url must not be sent to other site
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+	entry.WithFields(logrus.Fields{"status": status, "code": 0, "message": page.Message}).Info()
+}
+
+// verify parent action token in query & consume it with confirm code saved in server, returned claims are nil if token is not acceptable
+// token is accepted only if parent in token is still parent of student who applied outing
+// client sending invalid token too many times is locked out with the same policy as outing code lookup
+func (h *_default) consumeParentActionToken(c *gin.Context) (claims *jwtutil.ParentActionClaims, token parentActionToken, status, _code int, msg string) {
+	ctx := context.Background()
+	tracker, ipKey := attempt.NewTracker(h.redisClient), attempt.IPKey(c.ClientIP())
+	if locked, remain, _, _ := tracker.Check(ctx, attempt.OutingCodePolicy, ipKey); locked {
+		c.Header("Retry-After", fmt.Sprintf("%.0f", remain.Seconds()))
+		status, _code = http.StatusTooManyRequests, gwcode.TooManyFailedAttempts
+		msg = fmt.Sprintf("too many failed attempts with parent action token, please retry after %s", remain.Round(time.Second).String())
+		return
+	}
+
+	parsedClaims, err := jwtutil.ParseParentActionClaimsFrom(c.Query("token"))
+	if err != nil || parsedClaims.OutingUUID != c.Param("outing_uuid") || parsedClaims.Action != c.Param("action") {
+		_, _ = tracker.Fail(ctx, attempt.OutingCodePolicy, ipKey)
+		status, _code = http.StatusUnauthorized, gwcode.InvalidParentActionToken
+		msg = "parent action token is invalid, expired or not issued for this outing & action"
+		return
+	}
+
+	// get & delete key in one transaction, only one request can delete key so token is consumed only once even if requested concurrently
+	key := fmt.Sprintf(parentActionTokenKeyFormat, parsedClaims.Id)
+	var getCmd *redis.StringCmd
+	var delCmd *redis.IntCmd
+	_, err = h.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd, delCmd = pipe.Get(ctx, key), pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		status, _code = http.StatusInternalServerError, 0
+		msg = fmt.Sprintf("unable to consume parent action token in redis, err: %v", err)
+		return
+	}
+	if delCmd.Val() == 0 {
+		status, _code = http.StatusGone, gwcode.ConsumedParentActionToken
+		msg = "parent action link was already used"
+		return
+	}
+	if err = json.Unmarshal([]byte(getCmd.Val()), &token); err != nil {
+		status, _code = http.StatusInternalServerError, 0
+		msg = fmt.Sprintf("unable to unmarshal parent action token saved in redis, err: %v", err)
+		return
+	}
+
+	// get top span from middleware
+	inAdvanceTopSpan, _ := c.Get("TopSpan")
+	topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+	parentUUID, err := h.getParentUUIDOfOuting(rpcContext(c), parsedClaims.OutingUUID, token.IssuerUUID, c.GetHeader("X-Request-Id"), topSpan)
+	if err != nil {
+		h.restoreParentActionToken(parsedClaims, token)
+		status, _code, msg = parentOfOutingErrStatus(err)
+		return
+	}
+	if parentUUID != parsedClaims.ParentUUID {
+		_, _ = tracker.Fail(ctx, attempt.OutingCodePolicy, ipKey)
+		status, _code = http.StatusForbidden, gwcode.InvalidParentActionToken
+		msg = "parent action token is not issued for parent of student who applied this outing"
+		return
+	}
+
+	claims = parsedClaims
+	return
+}
+
+// save consumed token again with remaining lifetime so that parent can retry with the same link
+func (h *_default) restoreParentActionToken(claims *jwtutil.ParentActionClaims, token parentActionToken) {
+	remain := time.Until(time.Unix(claims.ExpiresAt, 0))
+	if remain <= 0 {
+		return
+	}
+	value, _ := json.Marshal(token)
+	h.redisClient.Set(context.Background(), fmt.Sprintf(parentActionTokenKeyFormat, claims.Id), value, remain)
+}
+
+// return uuid of parent of student who applied outing, user uuid is sent to services to read outing & parent inform
+func (h *_default) getParentUUIDOfOuting(ctx context.Context, outingUUID, userUUID, reqID string, topSpan opentracing.Span) (string, error) {
+	outingReq := new(outingproto.GetOutingInformRequest)
+	outingReq.Uuid = userUUID
+	outingReq.OutingId = outingUUID
+	var outingResp *outingproto.GetOutingInformResponse
+	if err := h.callService(ctx, topic.OutingServiceName, "GetOutingInform", reqID, topSpan, outingReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			outingResp, rpcErr = h.outingService.GetOutingInform(ctx, outingReq, callOpts...)
+			return outingResp, rpcErr
+		}); err != nil {
+		return "", err
+	}
+	if outingResp.Status != http.StatusOK {
+		return "", &serviceError{status: int(outingResp.Status), code: int(outingResp.Code), message: outingResp.Msg}
+	}
+
+	parentReq := new(authproto.GetParentWithStudentUUIDRequest)
+	parentReq.UUID = userUUID
+	parentReq.StudentUUID = outingResp.StudentUuid
+	var parentResp *authproto.GetParentWithStudentUUIDResponse
+	if err := h.callService(ctx, topic.AuthServiceName, "GetParentWithStudentUUID", reqID, topSpan, parentReq,
+		func(ctx context.Context, callOpts ...client.CallOption) (_ interface{}, rpcErr error) {
+			parentResp, rpcErr = h.authService.GetParentWithStudentUUID(ctx, parentReq, callOpts...)
+			return parentResp, rpcErr
+		}); err != nil {
+		return "", err
+	}
+	if parentResp.Status != http.StatusOK {
+		return "", &serviceError{status: int(parentResp.Status), code: int(parentResp.Code), message: parentResp.Message}
+	}
+	if parentResp.ParentUUID == "" {
+		return "", errors.New(fmt.Sprintf("GetParentWithStudentUUID returns empty parent uuid, student uuid: %s", outingResp.StudentUuid))
+	}
+	return parentResp.ParentUUID, nil
+}
+
+// return status, code & message to respond from error returned in getParentUUIDOfOuting
+func parentOfOutingErrStatus(err error) (status, _code int, msg string) {
+	if srvErr, ok := err.(*serviceError); ok {
+		return srvErr.status, srvErr.code, srvErr.message
+	}
+	return http.StatusInternalServerError, 0, err.Error()
+}
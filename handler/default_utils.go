@@ -1,15 +1,28 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"gateway/consul"
 	consulagent "gateway/consul/agent"
+	"gateway/tool/i18n"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	respcode "gateway/utils/code/golang"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/eapache/go-resiliency/breaker"
 	"github.com/gin-gonic/gin"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
 	"net/http"
 	"strings"
+	"time"
 )
 
 func (_ *_default) checkIfAuthenticated(c *gin.Context) (ok bool, claims jwtutil.UUIDClaims, code int, msg string) {
@@ -78,3 +91,110 @@ func (h *_default) getStatusCodeFromConsulErr(err error) (status, _code int, msg
 	}
 	return
 }
+
+// serviceError is error returned from callService, having status & code to respond (add in v.1.0.6)
+type serviceError struct {
+	status  int
+	code    int
+	message string
+	gateway bool // true if error occurs in gateway (consul, circuit breaker, etc ...), not responded from service (add in v.1.0.6)
+}
+
+func (e *serviceError) Error() string { return e.message }
+
+// return message of error in language negotiated with Accept-Language, message responded from service is not changed
+func (e *serviceError) localizedMessage(acceptLanguage string) string {
+	if !e.gateway {
+		return e.message
+	}
+	return i18n.Localize(acceptLanguage, e.status, e.code, e.message)
+}
+
+// return message of error occurred in gateway while calling service (consul, circuit breaker, micro error) in language
+// negotiated with Accept-Language, developer message (msg) is still written in log (add in v.1.0.6)
+func localizeGatewayErr(c *gin.Context, status, _code int, msg string) string {
+	return i18n.Localize(c.GetHeader("Accept-Language"), status, _code, msg)
+}
+
+// return status & code in extensions of GraphQL error
+func (e *serviceError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"status": e.status, "code": e.code}
+}
+
+// return node of service selected from consul & set it in gin context so that access logger logs upstream node
+// add in v.1.0.6
+func (h *_default) selectNode(c *gin.Context, srvName consul.ServiceName) (selectedNode *registry.Node, err error) {
+	if selectedNode, err = h.consulAgent.GetNextServiceNode(srvName); err == nil {
+		c.Set("SelectedNode", *selectedNode)
+	}
+	return
+}
+
+// return context to call rpc in handler, which is context of section set in sub context of dashboard so that rpc of
+// timed-out section is canceled, rpc of other request is not canceled with request context even if client disconnects
+// add in v.1.0.6
+func rpcContext(c *gin.Context) context.Context {
+	if value, ok := c.Get("RPCContext"); ok {
+		if ctx, ok := value.(context.Context); ok {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// this method is to call rpc method of service in node selected from consul with circuit breaker & span of tracer
+// it is used in handler which doesn't respond result of one rpc call directly (GraphQL resolver, etc ...)
+// rpc is canceled with ctx, so call of timed-out dashboard section or GraphQL field doesn't keep running
+// add in v.1.0.6
+func (h *_default) callService(ctx context.Context, srvName consul.ServiceName, method, reqID string, topSpan opentracing.Span, rpcReq interface{},
+	call func(ctx context.Context, callOpts ...client.CallOption) (rpcResp interface{}, rpcErr error)) error {
+	selectedNode, err := h.consulAgent.GetNextServiceNode(srvName)
+	if err != nil {
+		status, _code, msg := h.getStatusCodeFromConsulErr(err)
+		return &serviceError{status: status, code: _code, message: msg, gateway: true}
+	}
+
+	h.mutex.Lock()
+	if _, ok := h.breakers[selectedNode.Id]; !ok {
+		h.breakers[selectedNode.Id] = breaker.New(h.BreakerCfg.ErrorThreshold, h.BreakerCfg.SuccessThreshold, h.BreakerCfg.Timeout)
+	}
+	nodeBreaker := h.breakers[selectedNode.Id]
+	h.mutex.Unlock()
+
+	err = nodeBreaker.Run(func() (rpcErr error) {
+		srvSpan := h.tracer.StartSpan(method, opentracing.ChildOf(topSpan.Context()))
+		ctxForReq := metadata.Set(ctx, "X-Request-Id", reqID)
+		ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, srvSpan.Context())
+		callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
+		var rpcResp interface{}
+		rpcResp, rpcErr = call(ctxForReq, callOpts...)
+		srvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
+		srvSpan.Finish()
+		return
+	})
+
+	switch rpcErr := err.(type) {
+	case nil:
+		return nil
+	case *errors.Error:
+		switch rpcErr.Code {
+		case http.StatusRequestTimeout:
+			msg := fmt.Sprintf("request time out for %s service, detail: %s", method, rpcErr.Detail)
+			return &serviceError{status: http.StatusRequestTimeout, code: 0, message: msg, gateway: true}
+		default:
+			msg := fmt.Sprintf("%s returns unexpected micro error, code: %d, detail: %s", method, rpcErr.Code, rpcErr.Detail)
+			return &serviceError{status: http.StatusInternalServerError, code: 0, message: msg, gateway: true}
+		}
+	default:
+		switch rpcErr {
+		case breaker.ErrBreakerOpen:
+			msg := fmt.Sprintf("circuit breaker is open (service id: %s, time out: %s)", selectedNode.Id, h.BreakerCfg.Timeout.String())
+			_ = h.consulAgent.FailTTLHealth(selectedNode.Metadata["CheckID"], breaker.ErrBreakerOpen.Error())
+			time.AfterFunc(h.BreakerCfg.Timeout, func() { _ = h.consulAgent.PassTTLHealth(selectedNode.Metadata["CheckID"], "close circuit breaker") })
+			return &serviceError{status: http.StatusServiceUnavailable, code: code.CircuitBreakerOpen, message: msg, gateway: true}
+		default:
+			msg := fmt.Sprintf("%s returns unexpected type of error, err: %s", method, rpcErr.Error())
+			return &serviceError{status: http.StatusInternalServerError, code: 0, message: msg, gateway: true}
+		}
+	}
+}
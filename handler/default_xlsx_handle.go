@@ -4,12 +4,13 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"gateway/entity"
 	authproto "gateway/proto/golang/auth"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"gateway/tool/tracing"
 	code "gateway/utils/code/golang"
 	topic "gateway/utils/topic/golang"
 	"github.com/360EntSecGroup-Skylar/excelize/v2"
@@ -20,7 +21,6 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -53,12 +53,12 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 	// get bound request entry from middleware
 	inAdvanceReq, _ := c.Get("Request")
 	receivedReq, _ := inAdvanceReq.(*entity.AddUnsignedStudentsFromExcelRequest)
-	reqBytes, _ := json.Marshal(receivedReq)
+	reqBytes, _ := redact.Marshal(receivedReq)
 
 	if !strings.HasSuffix(receivedReq.Excel.Filename, ".xlsx") {
 		status, _code := http.StatusBadRequest, code.IntegrityInvalidRequest
 		msg := fmt.Sprintf("formatting of Excel file must be .xlsx, file name: %s", receivedReq.Excel.Filename)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 		return
 	}
@@ -67,7 +67,7 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 	if err != nil {
 		status, _code := http.StatusBadRequest, code.IntegrityInvalidRequest
 		msg := fmt.Sprintf("unable to open excel file, file name: %s, err: %v", receivedReq.Excel.Filename, err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 		return
 	}
@@ -79,7 +79,7 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 	if err != nil {
 		status, _code := http.StatusBadRequest, code.IntegrityInvalidRequest
 		msg := fmt.Sprintf("unable to read excel file, file name: %s, err: %v", receivedReq.Excel.Filename, err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 		return
 	}
@@ -102,7 +102,7 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 		if err != nil {
 			status, _code := http.StatusBadRequest, 0
 			msg := err.Error()
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 			return
 		}
@@ -138,7 +138,7 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 		case studentNumberExist, nameExist, phoneNumberExist:
 			status, _code := http.StatusBadRequest, 0
 			msg := fmt.Sprintf("각각 학번, 성명, 전화번호와 관련된 속성이 모두 존재해야합니다, sheet: %s", sheet)
-			c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 			return
 		}
@@ -177,7 +177,7 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 			case studentNumberRegex.MatchString(studentNumber), nameRegex.MatchString(name), phoneNumberRegex.MatchString(phoneNumber):
 				status, _code := http.StatusBadRequest, 0
 				msg := fmt.Sprintf("학번, 성명, 전화번호와 관련된 값이 옳바르지 않습니다. (%s %s %s) sheet: %s", studentNumber, name, phoneNumber, sheet)
-				c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+				envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 				entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Info()
 				return
 			}
@@ -212,10 +212,10 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 	// student 0개면 빠꾸
 
 	// get service node
-	selectedNode, err := h.consulAgent.GetNextServiceNode(topic.AuthServiceName)
+	selectedNode, err := h.selectNode(c, topic.AuthServiceName)
 	if err != nil {
 		status, _code, msg := h.getStatusCodeFromConsulErr(err)
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -223,16 +223,16 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 
 	// send gRPC request
 	authSrvSpan := h.tracer.StartSpan("AddUnsignedStudents", opentracing.ChildOf(topSpan.Context()))
-	ctxForReq := context.Background()
+	ctxForReq := rpcContext(c)
 	ctxForReq = metadata.Set(ctxForReq, "X-Request-Id", reqID)
-	ctxForReq = metadata.Set(ctxForReq, "Span-Context", authSrvSpan.Context().(jaeger.SpanContext).String())
+	ctxForReq = tracing.ContextWithSpanContext(ctxForReq, h.tracer, authSrvSpan.Context())
 	rpcReq := &authproto.AddUnsignedStudentsRequest{
 		UUID:     uuidClaims.UUID,
 		Students: studentsForReq,
 	}
 	callOpts := append(h.DefaultCallOpts, client.WithAddress(selectedNode.Address))
 	rpcResp, rpcErr := h.authService.AddUnsignedStudents(ctxForReq, rpcReq, callOpts...)
-	authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", rpcReq), log.Object("response", rpcResp), log.Error(rpcErr))
+	authSrvSpan.SetTag("X-Request-Id", reqID).LogFields(log.Object("request", redact.Value(rpcReq)), log.Object("response", redact.Value(rpcResp)), log.Error(rpcErr))
 	authSrvSpan.Finish()
 
 	switch rpcErr := err.(type) {
@@ -248,13 +248,13 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 			msg = fmt.Sprintf("AddUnsignedStudents returns unexpected micro error, code: %d, detail: %s", rpcErr.Code, rpcErr.Detail)
 			status, _code = http.StatusInternalServerError, 0
 		}
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": localizeGatewayErr(c, status, _code, msg)})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	default:
 		status, _code := http.StatusInternalServerError, 0
 		msg := fmt.Sprintf("AddUnsignedStudents returns unexpected type of error, err: %s", rpcErr.Error())
-		c.JSON(status, gin.H{"status": status, "code": _code, "message": msg})
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg, "request": string(reqBytes)}).Error()
 		return
 	}
@@ -264,14 +264,14 @@ func (h *_default) AddUnsignedStudentsFromExcel(c *gin.Context) {
 		status, _code := http.StatusCreated, 0
 		sendResp := gin.H{"status": status, "code": _code, "message": rpcResp.Message,
 			"req_student_count": len(studentsForReq), "no_add_count": rpcResp.NoAddCount, "add_count": rpcResp.AddCount}
-		c.JSON(status, sendResp)
-		respBytes, _ := json.Marshal(sendResp)
+		envelope.JSON(c, status, sendResp)
+		respBytes, _ := redact.Marshal(sendResp)
 		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": rpcResp.Message, "response": string(respBytes), "request": string(reqBytes)}).Info()
 	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Error()
 	default:
-		c.JSON(int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
+		envelope.JSON(c, int(rpcResp.Status), gin.H{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message})
 		entry.WithFields(logrus.Fields{"status": rpcResp.Status, "code": rpcResp.Code, "message": rpcResp.Message, "request": string(reqBytes)}).Info()
 	}
 
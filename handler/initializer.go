@@ -1,28 +1,101 @@
 package handler
 
 import (
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
-var naverClientID string
-var naverClientSecret string
-var consulIndexHeader string
-var snsTopicArn string
+// Config is configuration of handler read from environment variable, which was read in init function before v.1.0.6
+// it is read with ConfigFromEnv in main & set with Configuration when handler is built, so importing handler doesn't
+// require environment variable (change in v.1.0.6)
+type Config struct {
+	NaverClientID             string
+	NaverClientSecret         string
+	ConsulIndexHeader         string
+	SNSTopicArn               string
+	ParentActionLinkBaseURL   string        // add in v.1.0.6
+	ParentActionLinkTTL       time.Duration // add in v.1.0.6
+	DashboardSectionTimeout   time.Duration // add in v.1.0.6
+	GraphQLMaxDepth           int           // add in v.1.0.6
+	GraphQLMaxComplexity      int           // add in v.1.0.6
+	GraphQLPersistedOnly      bool          // add in v.1.0.6
+	EventStreamMaxConnections int           // add in v.1.0.6
+}
+
+// return configuration having default value of optional environment variable
+func DefaultConfig() Config {
+	return Config{
+		ParentActionLinkTTL:       time.Hour * 24,
+		DashboardSectionTimeout:   time.Second * 2,
+		GraphQLMaxDepth:           6,
+		GraphQLMaxComplexity:      200,
+		EventStreamMaxConnections: 3,
+	}
+}
 
-func init() {
-	if naverClientID = os.Getenv("NAVER_CLIENT_ID"); naverClientID == "" {
-		log.Fatal("please set NAVER_CLIENT_ID in environment variable")
+// return configuration read from environment variable, error is returned if required one is not set or value is invalid
+func ConfigFromEnv() (cfg Config, err error) {
+	cfg = DefaultConfig()
+	if cfg.NaverClientID = os.Getenv("NAVER_CLIENT_ID"); cfg.NaverClientID == "" {
+		err = errors.New("please set NAVER_CLIENT_ID in environment variable")
+		return
+	}
+	if cfg.NaverClientSecret = os.Getenv("NAVER_CLIENT_SECRET"); cfg.NaverClientSecret == "" {
+		err = errors.New("please set NAVER_CLIENT_SECRET in environment variable")
+		return
+	}
+	if cfg.ConsulIndexHeader = os.Getenv("CONSUL_INDEX_HEADER"); cfg.ConsulIndexHeader == "" {
+		err = errors.New("please set CONSUL_INDEX_HEADER in environment variable")
+		return
+	}
+	if cfg.SNSTopicArn = os.Getenv("SNS_TOPIC_ARN"); cfg.SNSTopicArn == "" {
+		err = errors.New("please set SNS_TOPIC_ARN in environment variable")
+		return
+	}
+	if cfg.ParentActionLinkBaseURL = os.Getenv("PARENT_ACTION_LINK_BASE_URL"); cfg.ParentActionLinkBaseURL == "" {
+		err = errors.New("please set PARENT_ACTION_LINK_BASE_URL in environment variable")
+		return
+	}
+	if ttl := os.Getenv("PARENT_ACTION_LINK_TTL"); ttl != "" {
+		if cfg.ParentActionLinkTTL, err = time.ParseDuration(ttl); err != nil || cfg.ParentActionLinkTTL <= 0 {
+			err = errors.New(fmt.Sprintf("PARENT_ACTION_LINK_TTL must be positive duration string (ex. 24h), value: %s", ttl))
+			return
+		}
+	}
+	if timeout := os.Getenv("DASHBOARD_SECTION_TIMEOUT"); timeout != "" {
+		if cfg.DashboardSectionTimeout, err = time.ParseDuration(timeout); err != nil || cfg.DashboardSectionTimeout <= 0 {
+			err = errors.New(fmt.Sprintf("DASHBOARD_SECTION_TIMEOUT must be positive duration string (ex. 2s), value: %s", timeout))
+			return
+		}
+	}
+	if depth := os.Getenv("GRAPHQL_MAX_DEPTH"); depth != "" {
+		if cfg.GraphQLMaxDepth, err = strconv.Atoi(depth); err != nil || cfg.GraphQLMaxDepth <= 0 {
+			err = errors.New(fmt.Sprintf("GRAPHQL_MAX_DEPTH must be positive integer, value: %s", depth))
+			return
+		}
 	}
-	if naverClientSecret = os.Getenv("NAVER_CLIENT_SECRET"); naverClientSecret == "" {
-		log.Fatal("please set NAVER_CLIENT_SECRET in environment variable")
+	if complexity := os.Getenv("GRAPHQL_MAX_COMPLEXITY"); complexity != "" {
+		if cfg.GraphQLMaxComplexity, err = strconv.Atoi(complexity); err != nil || cfg.GraphQLMaxComplexity <= 0 {
+			err = errors.New(fmt.Sprintf("GRAPHQL_MAX_COMPLEXITY must be positive integer, value: %s", complexity))
+			return
+		}
 	}
-	if consulIndexHeader = os.Getenv("CONSUL_INDEX_HEADER"); consulIndexHeader == "" {
-		log.Fatal("please set CONSUL_INDEX_HEADER in environment variable")
+	if persistedOnly := os.Getenv("GRAPHQL_PERSISTED_ONLY"); persistedOnly != "" {
+		if cfg.GraphQLPersistedOnly, err = strconv.ParseBool(persistedOnly); err != nil {
+			err = errors.New(fmt.Sprintf("GRAPHQL_PERSISTED_ONLY must be boolean string (true or false), value: %s", persistedOnly))
+			return
+		}
 	}
-	if snsTopicArn = os.Getenv("SNS_TOPIC_ARN"); snsTopicArn == "" {
-		log.Fatal("please set SNS_TOPIC_ARN in environment variable")
+	if maxConns := os.Getenv("EVENT_STREAM_MAX_CONNECTIONS"); maxConns != "" {
+		if cfg.EventStreamMaxConnections, err = strconv.Atoi(maxConns); err != nil || cfg.EventStreamMaxConnections <= 0 {
+			err = errors.New(fmt.Sprintf("EVENT_STREAM_MAX_CONNECTIONS must be positive integer, value: %s", maxConns))
+			return
+		}
 	}
+	return
 }
 
 var limitTableForNaver = map[string]bool{}
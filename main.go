@@ -15,8 +15,14 @@ import (
 	scheduleproto "gateway/proto/golang/schedule"
 	customrouter "gateway/router"
 	"gateway/subscriber"
+	"gateway/tool/audit"
+	"gateway/tool/cursor"
 	"gateway/tool/env"
+	"gateway/tool/graphql"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/keyevent"
 	customlogrus "gateway/tool/logrus"
+	"gateway/tool/tracing"
 	topic "gateway/utils/topic/golang"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -28,11 +34,11 @@ import (
 	grpccli "github.com/micro/go-micro/v2/client/grpc"
 	"github.com/micro/go-micro/v2/client/selector"
 	"github.com/sirupsen/logrus"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -54,13 +60,13 @@ func main() {
 			topic.OutingServiceName, topic.ScheduleServiceName, topic.AnnouncementServiceName}),
 	)
 
-	// create jaeger connection
-	jaegerAddr := env.GetAndFatalIfNotExits("JAEGER_ADDRESS")
-	apiTracer, closer, err := jaegercfg.Configuration{
-		ServiceName: "DMS.SMS.v1.api.gateway", // add const in topic
-		Reporter: &jaegercfg.ReporterConfig{LogSpans: true, LocalAgentHostPort: jaegerAddr},
-		Sampler: &jaegercfg.SamplerConfig{Type: jaeger.SamplerTypeConst, Param: 1},
-	}.NewTracer()
+	// create OpenTelemetry tracer exporting with OTLP (change from jaeger in v.1.0.6)
+	apiTracer, closer, err := tracing.New(tracing.Config{
+		ServiceName:  "DMS.SMS.v1.api.gateway", // add const in topic
+		OTLPEndpoint: env.GetAndFatalIfNotExits("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		Sampler:      os.Getenv("OTEL_TRACES_SAMPLER"),     // parentbased_always_on if not set
+		SamplerArg:   os.Getenv("OTEL_TRACES_SAMPLER_ARG"), // fraction or traces per second for sampler
+	})
 	if err != nil {
 		log.Fatalf("error while creating new tracer for service, err: %v", err)
 	}
@@ -101,13 +107,60 @@ func main() {
 	scheduleSrvCli := scheduleproto.NewScheduleSrv("schedule", gRPCCli)
 	announcementSrvCli := announcementproto.NewAnnouncementSrv("announcement", gRPCCli)
 
+	// create store of audit record about privileged operations (add in v.1.0.6)
+	auditPath := os.Getenv("AUDIT_JSONL_PATH")
+	if auditPath == "" {
+		auditPath = "/usr/share/gateway/audit/audit.jsonl"
+	}
+	auditStore, err := audit.NewJSONLStore(auditPath)
+	if err != nil {
+		log.Fatalf("unable to create audit store, err: %v", err)
+	}
+
+	// load allowlist of GraphQL persisted queries if file path is set (add in v.1.0.6)
+	graphQLPersistedQueries := graphql.PersistedQueries{}
+	if path := os.Getenv("GRAPHQL_PERSISTED_QUERIES"); path != "" {
+		if graphQLPersistedQueries, err = graphql.LoadPersistedQueries(path); err != nil {
+			log.Fatalf("unable to load GraphQL persisted queries, err: %v", err)
+		}
+	}
+
+	compressionMinSize := 1024 // response smaller than this size (bytes) is not compressed (add in v.1.0.6)
+	if size := os.Getenv("COMPRESSION_MIN_SIZE"); size != "" {
+		if compressionMinSize, err = strconv.Atoi(size); err != nil || compressionMinSize < 0 {
+			log.Fatalf("COMPRESSION_MIN_SIZE must be non-negative integer, value: %s\n", size)
+		}
+	}
+	compressionContentTypes := []string{"application/json", "application/msgpack", "application/x-msgpack", "application/x-protobuf"}
+	if contentTypes := os.Getenv("COMPRESSION_CONTENT_TYPES"); contentTypes != "" {
+		compressionContentTypes = strings.Split(strings.ReplaceAll(contentTypes, " ", ""), ",")
+	}
+
+	// load secret keys signing access token, parent action token & cursor (add in v.1.0.6)
+	if err := jwtutil.LoadKeysFromEnv(); err != nil {
+		log.Fatal(err)
+	}
+	if err := cursor.LoadKeyFromEnv(); err != nil {
+		log.Fatal(err)
+	}
+
+	// read configuration of handler from environment variable (add in v.1.0.6)
+	handlerCfg, err := handler.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// create http request & event handler
 	defaultHandler := handler.Default(
+		handler.Configuration(handlerCfg), // add in v.1.0.6
 		handler.ConsulAgent(consulAgent),
 		handler.Validate(validator.New()),
 		handler.Tracer(apiTracer),
 		handler.AWSSession(awsSession),
 		handler.RedisClient(redisCli),
+		handler.AuditStore(auditStore), // add in v.1.0.6
+		handler.GraphQLPersistedQueries(graphQLPersistedQueries), // add in v.1.0.6
+		handler.CompressionMinSize(compressionMinSize),            // add in v.1.0.6
 		handler.Location(time.UTC),
 		handler.AuthService(authSrvCli),
 		handler.ClubService(clubSrvCli),
@@ -133,28 +186,24 @@ func main() {
 		//}),
 		subscriber.RedisListener(redisDelTopic, defaultHandler.DeleteAssociatedRedisKey, 5), // add in v.1.0.3
 		subscriber.RedisListener(redisSetTopic, defaultHandler.SetRedisKeyWithResponse, 5), // add in v.1.0.4
+		subscriber.RedisListener(keyevent.Topic(redisDelTopic), defaultHandler.PublishStudentEvent, 5), // add in v.1.0.6
+		defaultHandler.ListenStudentEvents, // add in v.1.0.6
 	)
 
-	// create logger & add hooks
-	if _, err := os.Stat("/usr/share/filebeat/log/dms-sms"); os.IsNotExist(err) {
-		if err = os.MkdirAll("/usr/share/filebeat/log/dms-sms", os.ModePerm); err != nil { log.Fatal(err) }
-	}
-	authLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/auth.log", logrus.Fields{"service": "auth"})
-	clubLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/club.log", logrus.Fields{"service": "club"})
-	outingLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/outing.log", logrus.Fields{"service": "outing"})
-	scheduleLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/schedule.log", logrus.Fields{"service": "schedule"})
-	announcementLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/announcement.log", logrus.Fields{"service": "announcement"})
-	openApiLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/open-api.log", logrus.Fields{"service": "open-api"})
-	excelApiLogger := customlogrus.New("/usr/share/filebeat/log/dms-sms/excel-api.log", logrus.Fields{"service": "excel-api"})
+	// create logger of access log, loggers per route group are created in RegisterRoutes (change in v.1.0.6)
+	accessLogger := customlogrus.New("access", logrus.Fields{"service": "access"}) // add in v.1.0.6
 
 	// create custom router & register function to execute before run
 	gin.SetMode(gin.ReleaseMode)
 	globalRouter := customrouter.New(gin.Default())
+	globalRouter.BindHandlers(defaultHandler.BoundHandlers()...) // bind handlers with request entity before routing (add in v.1.0.6)
 	globalRouter.RegisterBeforeRun(
 		defaultHandler.ConsulChangeEventPublisher(),
 		consulAgent.ChangeAllServiceNodes,
 		defaultSubscriber.StartListening,
+		globalRouter.CheckRoutesDocumented, // add in v.1.0.6
 	)
+	globalRouter.RegisterOnShutdown(defaultHandler.CloseStudentEvents) // finish event streams which shutdown doesn't wait for (add in v.1.0.6)
 
 	// routing ping & pong API
 	healthCheckRouter := globalRouter.Group("/")
@@ -167,120 +216,86 @@ func main() {
 	consulWatchRouter.POST("/events/types/consul-change", defaultHandler.PublishConsulChangeEvent) // add in v.1.0.2
 
 	// register middleware in global router & handler
+	acceptRequestID := os.Getenv("ACCEPT_INCOMING_REQUEST_ID") == "true" // use X-Request-Id sent from web proxy (add in v.1.0.6)
+	accessLogSampleRate, accessLogSlowThreshold := 1.0, time.Second // log all request & request slower than 1s as slow (add in v.1.0.6)
+	if rate := os.Getenv("ACCESS_LOG_SAMPLE_RATE"); rate != "" {
+		if accessLogSampleRate, err = strconv.ParseFloat(rate, 64); err != nil || accessLogSampleRate < 0 || accessLogSampleRate > 1 {
+			log.Fatalf("ACCESS_LOG_SAMPLE_RATE must be float between 0 and 1, value: %s\n", rate)
+		}
+	}
+	if threshold := os.Getenv("ACCESS_LOG_SLOW_THRESHOLD"); threshold != "" {
+		if accessLogSlowThreshold, err = time.ParseDuration(threshold); err != nil {
+			log.Fatalf("ACCESS_LOG_SLOW_THRESHOLD must be duration string (ex. 500ms), value: %s\n", threshold)
+		}
+	}
+	coalescingLockTTL := time.Second * 3 // TTL of redis lock coalescing requests across replicas, 0 disables lock (add in v.1.0.6)
+	if ttl := os.Getenv("COALESCING_LOCK_TTL"); ttl != "" {
+		if coalescingLockTTL, err = time.ParseDuration(ttl); err != nil || coalescingLockTTL < 0 {
+			log.Fatalf("COALESCING_LOCK_TTL must be non-negative duration string (ex. 3s), value: %s\n", ttl)
+		}
+	}
+	batchConcurrency := 5 // max number of sub requests in batch request running at once (add in v.1.0.6)
+	if concurrency := os.Getenv("BATCH_CONCURRENCY"); concurrency != "" {
+		if batchConcurrency, err = strconv.Atoi(concurrency); err != nil || batchConcurrency <= 0 {
+			log.Fatalf("BATCH_CONCURRENCY must be positive integer, value: %s\n", concurrency)
+		}
+	}
+	graphQLEnabled := false // expose GraphQL facade API only if enabled (add in v.1.0.6)
+	if enabled := os.Getenv("GRAPHQL_ENABLED"); enabled != "" {
+		if graphQLEnabled, err = strconv.ParseBool(enabled); err != nil {
+			log.Fatalf("GRAPHQL_ENABLED must be boolean string (true or false), value: %s\n", enabled)
+		}
+	}
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
-	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization", "authorization", "Request-Security")
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "Authorization", "authorization", "Request-Security",
+		"traceparent", "tracestate", "X-Request-Id") // W3C trace context, request id header (add in v.1.0.6)
+	corsConfig.AllowHeaders = append(corsConfig.AllowHeaders, "If-None-Match") // conditional GET with ETag (add in v.1.0.6)
+	corsConfig.ExposeHeaders = append(corsConfig.ExposeHeaders, "ETag")
 	// run middleware before routing matching
 	globalRouter.Use(
-		cors.New(corsConfig),         // handle CORS request behind of AWS API Gateway
-		middleware.SecurityFilter(),  // filter if verified client with algorithm using aes256
-		middleware.Correlator(),      // set X-Request-ID field in request header to express correlate
-		// middleware.DosDetector(),  // count request number per client IP to detect dos attack
+		cors.New(corsConfig),                   // handle CORS request behind of AWS API Gateway
+		middleware.Correlator(acceptRequestID), // set X-Request-ID field in request header to express correlate
+		middleware.AccessLogger(accessLogger, accessLogSampleRate, accessLogSlowThreshold), // log every request including aborted one (add in v.1.0.6)
+		middleware.SecurityFilter( // filter if verified client with algorithm using aes256
+			middleware.ExemptParentActionLink("/v1/outings/uuid/:outing_uuid/actions/:action"), // change in v.1.0.6
+			middleware.ExemptRoutes("/openapi.json", "/swagger", "/swagger/assets/:asset")), // opened in browser without proxy
+		// middleware.DosDetector(),            // count request number per client IP to detect dos attack
 	)
+	// routing OpenAPI document & Swagger UI about APIs routed in custom router group (add in v.1.0.6)
+	// these routes are exempted from security filter above, so document can be read in browser
+	apiDocRouter := globalRouter.Group("/")
+	apiDocRouter.GET("/openapi.json", globalRouter.OpenAPIHandler("DMS-SMS API Gateway", "1.0.6"))
+	apiDocRouter.GET("/swagger", globalRouter.SwaggerUIHandler("DMS-SMS API Gateway", "/openapi.json", "/swagger/assets"))
+	apiDocRouter.GET("/swagger/assets/:asset", globalRouter.SwaggerUIAssetHandler())
 	// run middleware after successful routing matching
-	router := globalRouter.CustomGroup("/",
-		middleware.GinHResponseWriter(),          // change ResponseWriter in *gin.Context to custom writer overriding that (add in v.1.0.3)
-		middleware.TracerSpanStarter(apiTracer),  // start, end top span of tracer & set log, tag about response (add in v.1.0.3)
-	)
-	router.Validator = validator.New()
-	redisHandler := middleware.RedisHandler(redisCli, apiTracer, redisSetTopic, redisDelTopic)
-
-	// routing auth service API
-	authRouter := router.CustomGroup("/", middleware.LogEntrySetter(authLogger))
-	// auth service api for admin
-	authRouter.POSTWithAuth("/v1/students", defaultHandler.CreateNewStudent)
-	authRouter.POSTWithAuth("/v1/parents", defaultHandler.CreateNewParent)
-	authRouter.POST("/v1/login/admin", defaultHandler.LoginAdminAuth)
-	authRouter.POSTWithAuth("/v1/join-sms/unsigned-students", defaultHandler.SendJoinSMSToUnsignedStudents)
-	// auth service api for student
-	authRouter.POST("/v1/login/student", defaultHandler.LoginStudentAuth)
-	authRouter.PUTWithAuth("/v1/students/uuid/:student_uuid/password", defaultHandler.ChangeStudentPW)
-	authRouter.GETWithAuth("/v1/students/uuid/:student_uuid", defaultHandler.GetStudentInformWithUUID)
-	authRouter.GETWithAuth("/v1/student-uuids", defaultHandler.GetStudentUUIDsWithInform)
-	authRouter.POSTWithAuth("/v1/students/with-uuids", defaultHandler.GetStudentInformsWithUUIDs)
-	authRouter.GETWithAuth("/v1/students/uuid/:student_uuid/parent", defaultHandler.GetParentWithStudentUUID)
-	authRouter.GET("/v1/students/auth-code/:auth_code", defaultHandler.GetUnsignedStudentWithAuthCode)
-	authRouter.POST("/v1/students/with-code", defaultHandler.CreateNewStudentWithAuthCode)
-	// auth service api for teacher
-	authRouter.POST("/v1/teachers", defaultHandler.CreateNewTeacher)
-	authRouter.POST("/v1/login/teacher", defaultHandler.LoginTeacherAuth)
-	authRouter.POST("/v1/login/teacher/with-pick", defaultHandler.LoginTeacherAuthWithPICK)
-	authRouter.PUTWithAuth("/v1/teachers/uuid/:teacher_uuid/password", defaultHandler.ChangeTeacherPW)
-	authRouter.GETWithAuth("/v1/teachers/uuid/:teacher_uuid", defaultHandler.GetTeacherInformWithUUID)
-	authRouter.GETWithAuth("/v1/teacher-uuids", defaultHandler.GetTeacherUUIDsWithInform)
-	authRouter.PATCHWithAuth("/v1/teachers/uuid/:teacher_uuid", defaultHandler.ChangeTeacherInform)
-	// auth service api for parent
-	authRouter.POST("/v1/login/parent", defaultHandler.LoginParentAuth)
-	authRouter.PUTWithAuth("/v1/parents/uuid/:parent_uuid/password", defaultHandler.ChangeParentPW)
-	authRouter.GETWithAuth("/v1/parents/uuid/:parent_uuid", defaultHandler.GetParentInformWithUUID)
-	authRouter.GETWithAuth("/v1/parent-uuids", defaultHandler.GetParentUUIDsWithInform)
-	authRouter.GETWithAuth("/v1/parents/uuid/:parent_uuid/children", defaultHandler.GetChildrenInformsWithUUID)
-
-	// routing club service API
-	clubRouter := router.CustomGroup("/", middleware.LogEntrySetter(clubLogger))
-	// club service api for admin
-	clubRouter.POSTWithAuth("/v1/clubs", defaultHandler.CreateNewClub)
-	// club service api for student
-	clubRouter.GETWithAuth("/v1/clubs/sorted-by/update-time", defaultHandler.GetClubsSortByUpdateTime)
-	clubRouter.GETWithAuth("/v1/recruitments/sorted-by/create-time", defaultHandler.GetRecruitmentsSortByCreateTime)
-	clubRouter.GETWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.GetClubInformWithUUID)
-	clubRouter.GETWithAuth("/v1/clubs", defaultHandler.GetClubInformsWithUUIDs)
-	clubRouter.GETWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.GetRecruitmentInformWithUUID)
-	clubRouter.GETWithAuth("/v1/clubs/uuid/:club_uuid/recruitment-uuid", defaultHandler.GetRecruitmentUUIDWithClubUUID)
-	clubRouter.GETWithAuth("/v1/recruitment-uuids", defaultHandler.GetRecruitmentUUIDsWithClubUUIDs)
-	clubRouter.GETWithAuth("/v1/clubs/property/fields", defaultHandler.GetAllClubFields)
-	clubRouter.GETWithAuth("/v1/clubs/count", defaultHandler.GetTotalCountOfClubs)
-	clubRouter.GETWithAuth("/v1/recruitments/count", defaultHandler.GetTotalCountOfCurrentRecruitments)
-	clubRouter.GETWithAuth("/v1/leaders/uuid/:leader_uuid/club-uuid", defaultHandler.GetClubUUIDWithLeaderUUID)
-	// club service api for club leader
-	clubRouter.DELETEWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.DeleteClubWithUUID)
-	clubRouter.POSTWithAuth("/v1/clubs/uuid/:club_uuid/members", defaultHandler.AddClubMember)
-	clubRouter.DELETEWithAuth("/v1/clubs/uuid/:club_uuid/members/:student_uuid", defaultHandler.DeleteClubMember)
-	clubRouter.PUTWithAuth("/v1/clubs/uuid/:club_uuid/leader", defaultHandler.ChangeClubLeader)
-	clubRouter.PATCHWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.ModifyClubInform)
-	clubRouter.POSTWithAuth("/v1/recruitments", defaultHandler.RegisterRecruitment)
-	clubRouter.PATCHWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.ModifyRecruitment)
-	clubRouter.DELETEWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.DeleteRecruitment)
-
-	// routing outing service API
-	outingRouter := router.CustomGroup("/", middleware.LogEntrySetter(outingLogger))
-	outingRouter.POSTWithAuth("/v1/outings", defaultHandler.CreateOuting, redisHandler.CreateOuting()...)
-	outingRouter.GETWithAuth("/v1/students/uuid/:student_uuid/outings", defaultHandler.GetStudentOutings, redisHandler.GetStudentOutings()...)
-	outingRouter.GETWithAuth("/v1/outings/uuid/:outing_uuid", defaultHandler.GetOutingInform, redisHandler.GetOutingInform()...)
-	outingRouter.GETWithAuth("/v1/outings/uuid/:outing_uuid/card", defaultHandler.GetCardAboutOuting, redisHandler.GetCardAboutOuting()...)
-	outingRouter.POST("/v1/outings/uuid/:outing_uuid/actions/:action", defaultHandler.TakeActionInOuting, redisHandler.TakeActionInOuting()...)
-	outingRouter.GETWithAuth("/v1/outings/with-filter", defaultHandler.GetOutingWithFilter, redisHandler.GetOutingWithFilter()...)
-	outingRouter.GET("/v1/outings/code/:OCode", defaultHandler.GetOutingByOCode)
-	outingRouter.PATCHWithAuth("/v1/outings/uuid/:outing_uuid", defaultHandler.ModifyOuting, redisHandler.ModifyOuting()...)
-
-	// routing schedule service API
-	scheduleRouter := router.CustomGroup("/", middleware.LogEntrySetter(scheduleLogger))
-	scheduleRouter.POSTWithAuth("/v1/schedules", defaultHandler.CreateSchedule, redisHandler.CreateSchedule()...)
-	scheduleRouter.GETWithAuth("/v1/schedules/years/:year/months/:month", defaultHandler.GetSchedule, redisHandler.GetSchedule()...)
-	scheduleRouter.GETWithAuth("/v1/time-tables/years/:year/months/:month/days/:day", defaultHandler.GetTimeTable, redisHandler.GetTimeTable()...)
-	scheduleRouter.PATCHWithAuth("/v1/schedules/uuid/:schedule_uuid", defaultHandler.UpdateSchedule, redisHandler.UpdateSchedule()...)
-	scheduleRouter.DELETEWithAuth("/v1/schedules/uuid/:schedule_uuid", defaultHandler.DeleteSchedule, redisHandler.DeleteSchedule()...)
-
-	// routing announcement service API
-	announcementRouter := router.CustomGroup("/", middleware.LogEntrySetter(announcementLogger))
-	announcementRouter.POSTWithAuth("/v1/announcements", defaultHandler.CreateAnnouncement, redisHandler.CreateAnnouncement()...)
-	announcementRouter.GETWithAuth("/v1/announcements/types/:type", defaultHandler.GetAnnouncements, redisHandler.GetAnnouncements()...)
-	announcementRouter.GETWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.GetAnnouncementDetail, redisHandler.GetAnnouncementDetail()...)
-	announcementRouter.PATCHWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.UpdateAnnouncement, redisHandler.UpdateAnnouncement()...)
-	announcementRouter.DELETEWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.DeleteAnnouncement, redisHandler.DeleteAnnouncement()...)
-	announcementRouter.GETWithAuth("/v1/students/uuid/:student_uuid/announcement-check", defaultHandler.CheckAnnouncement, redisHandler.CheckAnnouncement()...)
-	announcementRouter.GETWithAuth("/v1/announcements/types/:type/query/:search_query", defaultHandler.SearchAnnouncements, redisHandler.SearchAnnouncements()...)
-	announcementRouter.GETWithAuth("/v1/announcements/writer-uuid/:writer_uuid", defaultHandler.GetMyAnnouncements, redisHandler.GetMyAnnouncements()...)
-
-	// routing open-api agent API
-	openApiRouter := router.CustomGroup("/", middleware.LogEntrySetter(openApiLogger))
-	openApiRouter.GETWithAuth("/naver-open-api/search/local", defaultHandler.GetPlaceWithNaverOpenAPI)
+	globalRouter.RegisterRoutes(defaultHandler, customrouter.RouteConfig{ // move routes into router in v.1.0.6
+		Tracer:                  apiTracer,
+		RedisClient:             redisCli,
+		RedisSetTopic:           redisSetTopic,
+		RedisDelTopic:           redisDelTopic,
+		CoalescingLockTTL:       coalescingLockTTL,
+		CompressionMinSize:      compressionMinSize,
+		CompressionContentTypes: compressionContentTypes,
+		AuditStore:              auditStore,
+		BatchConcurrency:        batchConcurrency,
+		GraphQLEnabled:          graphQLEnabled,
+		NewLogger: func(group string) *logrus.Logger {
+			return customlogrus.New(group, logrus.Fields{"service": group})
+		},
+	})
 
-	// routing excel handling API
-	excelApiRouter := router.CustomGroup("/", middleware.LogEntrySetter(excelApiLogger))
-	excelApiRouter.POSTWithAuth("/v1/unsigned-students/parsed-by/excel", defaultHandler.AddUnsignedStudentsFromExcel)
-	excelApiRouter.POSTWithAuth("/v1/unsigned-students/parsed-by/excel/sheets/:sheet", defaultHandler.AddUnsignedStudentsFromExcel)
+	// run server until SIGINT or SIGTERM is received (change in v.1.0.6)
+	runErr := globalRouter.Run(":80")
 
-	// run server
-	log.Fatal(globalRouter.Run(":80"))
+	// flush buffered audit records & logs (logstash, rotated file) after in-flight requests are finished (add in v.1.0.6)
+	if err := auditStore.Close(); err != nil {
+		log.Printf("unable to close audit store, err: %v\n", err)
+	}
+	if err := customlogrus.Close(); err != nil {
+		log.Printf("unable to close log sinks, err: %v\n", err)
+	}
+	if runErr != nil {
+		log.Fatalf("server stopped with error, err: %v\n", runErr)
+	}
 }
@@ -0,0 +1,84 @@
+// add file in v.1.0.6
+// access_logger.go is file that declare middleware logging every request with latency, size, upstream node & cache status
+
+package middleware
+
+import (
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"github.com/gin-gonic/gin"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type accessLogger struct {
+	logger        *logrus.Logger
+	sampleRate    float64       // fraction of 2xx, 3xx responses faster than slow threshold to log
+	slowThreshold time.Duration // request slower than this is always logged
+}
+
+// access logger must be registered before other middleware aborting request (SecurityFilter, Authenticator, etc ...)
+// so that aborted request is also logged after c.Next()
+func AccessLogger(l *logrus.Logger, sampleRate float64, slowThreshold time.Duration) gin.HandlerFunc {
+	return (&accessLogger{
+		logger:        l,
+		sampleRate:    sampleRate,
+		slowThreshold: slowThreshold,
+	}).logAccess
+}
+
+func (a *accessLogger) logAccess(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	latency := time.Since(start)
+	status := c.Writer.Status()
+	// streaming response (server-sent events) is open until client disconnects, so it is not regarded as slow one
+	streaming := strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "text/event-stream")
+	slow := !streaming && latency >= a.slowThreshold
+	if status < http.StatusBadRequest && !slow && rand.Float64() >= a.sampleRate {
+		return
+	}
+
+	fields := logrus.Fields{
+		"method":        c.Request.Method,
+		"route":         c.FullPath(),
+		"path":          c.Request.URL.Path,
+		"status":        status,
+		"latency_ms":    float64(latency.Microseconds()) / 1000,
+		"slow":          slow,
+		"streaming":     streaming,
+		"request_size":  c.Request.ContentLength,
+		"response_size": c.Writer.Size(),
+		"client_ip":     c.ClientIP(),
+		"X-Request-Id":  c.GetHeader("X-Request-Id"),
+		"trace_id":      c.GetString("TraceID"),
+		"cache_status":  c.GetString("CacheStatus"),
+	}
+	// code is read from envelope written in handler, because body may be compressed or encoded in other format
+	if resp, ok := envelope.FromContext(c); ok {
+		fields["code"] = resp.Code
+	}
+	if inAdvanceClaims, ok := c.Get("Claims"); ok {
+		fields["user_uuid"] = inAdvanceClaims.(jwtutil.UUIDClaims).UUID
+	}
+	if inAdvanceNode, ok := c.Get("SelectedNode"); ok {
+		node := inAdvanceNode.(registry.Node)
+		fields["upstream_node"] = node.Id
+		fields["upstream_address"] = node.Address
+	}
+
+	entry := a.logger.WithFields(fields)
+	switch {
+	case status >= http.StatusInternalServerError:
+		entry.Error("access")
+	case status >= http.StatusBadRequest || slow:
+		entry.Warn("access")
+	default:
+		entry.Info("access")
+	}
+}
@@ -0,0 +1,127 @@
+// add file in v.1.0.6
+// attempt_limiter.go is file that declare middleware delaying & locking out client or account failed too many times
+// in unauthenticated API (login, code lookup) to protect from brute-force attack
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"gateway/tool/attempt"
+	gwcode "gateway/tool/code"
+	"gateway/tool/envelope"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	systemlog "log"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// response status regarded as failed attempt (wrong password, not existing code, etc ...)
+var failedAttemptStatuses = map[int]bool{
+	http.StatusUnauthorized: true,
+	http.StatusNotFound:     true,
+}
+
+type attemptLimiter struct {
+	tracker *attempt.Tracker
+}
+
+func AttemptLimiter(cli *redis.Client) *attemptLimiter {
+	return &attemptLimiter{
+		tracker: attempt.NewTracker(cli),
+	}
+}
+
+// return handler tracking attempts per client ip with ipPolicy & per account with accountPolicy
+// account id is got from accountField of bound request entity, account is not tracked if accountField is blank
+func (a *attemptLimiter) Limiter(ipPolicy, accountPolicy attempt.Policy, accountField string) gin.HandlerFunc {
+	if accountField != "" && accountPolicy.Scope == "" {
+		systemlog.Fatalln("account policy must be set to track attempts per account")
+	}
+
+	return func(c *gin.Context) {
+		ctx := context.Background()
+		inAdvanceEntry, _ := c.Get("RequestLogEntry")
+		entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+		type tracked struct {
+			policy attempt.Policy
+			key    string
+		}
+		targets := []tracked{{ipPolicy, attempt.IPKey(c.ClientIP())}}
+		if accountField != "" {
+			if id := a.accountIDFromRequest(c, accountField); id != "" {
+				targets = append(targets, tracked{accountPolicy, attempt.AccountKey(id)})
+			}
+		}
+
+		// check lockout of all targets & use the longest delay among targets
+		var delay time.Duration
+		for _, target := range targets {
+			locked, remain, targetDelay, err := a.tracker.Check(ctx, target.policy, target.key)
+			if err != nil {
+				entry.WithFields(logrus.Fields{"scope": target.policy.Scope, "key": target.key}).Errorf("unable to check failed attempts, err: %v", err)
+				continue
+			}
+			if locked {
+				msg := fmt.Sprintf("too many failed attempts, please retry after %s", remain.Round(time.Second).String())
+				c.Header("Retry-After", fmt.Sprintf("%.0f", remain.Seconds()))
+				envelope.AbortJSON(c, http.StatusTooManyRequests, gin.H{
+					"status": http.StatusTooManyRequests, "code": gwcode.TooManyFailedAttempts, "message": msg,
+				})
+				entry.WithFields(logrus.Fields{"status": http.StatusTooManyRequests, "code": gwcode.TooManyFailedAttempts, "message": msg,
+					"scope": target.policy.Scope, "key": target.key}).Warn()
+				return
+			}
+			if targetDelay > delay {
+				delay = targetDelay
+			}
+		}
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-c.Request.Context().Done():
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		for _, target := range targets {
+			switch {
+			case failedAttemptStatuses[status]:
+				if locked, err := a.tracker.Fail(ctx, target.policy, target.key); err == nil && locked {
+					entry.WithFields(logrus.Fields{"scope": target.policy.Scope, "key": target.key}).Warn("locked out because of too many failed attempts")
+				}
+			case status >= http.StatusOK && status < http.StatusMultipleChoices:
+				_ = a.tracker.Succeed(ctx, target.policy, target.key)
+			}
+		}
+	}
+}
+
+// get value of field in request entity bound in RequestValidator
+func (a *attemptLimiter) accountIDFromRequest(c *gin.Context, field string) string {
+	inAdvanceReq, ok := c.Get("Request")
+	if !ok {
+		return ""
+	}
+
+	value := reflect.ValueOf(inAdvanceReq)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	if fieldValue := value.FieldByName(field); fieldValue.IsValid() && fieldValue.Kind() == reflect.String {
+		return fieldValue.String()
+	}
+	return ""
+}
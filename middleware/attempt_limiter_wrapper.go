@@ -0,0 +1,37 @@
+// add file in v.1.0.6
+// attempt_limiter_wrapper.go is file that declare method returning attempt limiter handler of each API
+
+package middleware
+
+import (
+	"gateway/tool/attempt"
+	"github.com/gin-gonic/gin"
+)
+
+func (a *attemptLimiter) LoginStudentAuth() gin.HandlerFunc {
+	return a.Limiter(attempt.LoginIPPolicy, attempt.StudentLoginPolicy, "StudentID")
+}
+
+func (a *attemptLimiter) LoginTeacherAuth() gin.HandlerFunc {
+	return a.Limiter(attempt.LoginIPPolicy, attempt.TeacherLoginPolicy, "TeacherID")
+}
+
+func (a *attemptLimiter) LoginTeacherAuthWithPICK() gin.HandlerFunc {
+	return a.Limiter(attempt.LoginIPPolicy, attempt.TeacherLoginPolicy, "TeacherID")
+}
+
+func (a *attemptLimiter) LoginParentAuth() gin.HandlerFunc {
+	return a.Limiter(attempt.LoginIPPolicy, attempt.ParentLoginPolicy, "ParentID")
+}
+
+func (a *attemptLimiter) LoginAdminAuth() gin.HandlerFunc {
+	return a.Limiter(attempt.LoginIPPolicy, attempt.AdminLoginPolicy, "AdminID")
+}
+
+func (a *attemptLimiter) GetUnsignedStudentWithAuthCode() gin.HandlerFunc {
+	return a.Limiter(attempt.AuthCodePolicy, attempt.Policy{}, "")
+}
+
+func (a *attemptLimiter) GetOutingByOCode() gin.HandlerFunc {
+	return a.Limiter(attempt.OutingCodePolicy, attempt.Policy{}, "")
+}
@@ -0,0 +1,82 @@
+// add file in v.1.0.6
+// audit_recorder.go is file that declare middleware recording privileged operation in audit store after handler run
+
+package middleware
+
+import (
+	"context"
+	"gateway/tool/audit"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"gateway/tool/redact"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+type auditRecorder struct {
+	store audit.Store
+}
+
+func AuditRecorder(store audit.Store) *auditRecorder {
+	return &auditRecorder{
+		store: store,
+	}
+}
+
+// return handler recording request in audit store, only if action param is in actions when actions are set
+func (a *auditRecorder) Recorder(actions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(actions) != 0 && !containsString(actions, c.Param("action")) {
+			return
+		}
+
+		inAdvanceClaims, _ := c.Get("Claims")
+		uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+		record := &audit.Record{
+			Time:      time.Now().UTC(),
+			ActorUUID: uuidClaims.UUID,
+			Method:    c.Request.Method,
+			Route:     c.FullPath(),
+			Path:      c.Request.URL.Path,
+			Targets:   map[string]string{},
+			Status:    c.Writer.Status(),
+			RequestID: c.GetHeader("X-Request-Id"),
+			TraceID:   c.GetString("TraceID"),
+		}
+		if inAdvanceReq, ok := c.Get("Request"); ok {
+			record.Request, _ = redact.Marshal(inAdvanceReq)
+		}
+
+		// targets are uuid in path parameter & uuid of resource created in response (ex. student_uuid)
+		for _, param := range c.Params {
+			record.Targets[param.Key] = param.Value
+		}
+		if resp, ok := envelope.FromContext(c); ok {
+			record.Code, record.Message = resp.Code, resp.Message
+			for key, value := range resp.Payload {
+				if str, ok := value.(string); ok && strings.HasSuffix(key, "_uuid") {
+					record.Targets[key] = str
+				}
+			}
+		}
+
+		if err := a.store.Append(context.Background(), record); err != nil {
+			inAdvanceEntry, _ := c.Get("RequestLogEntry")
+			entry, _ := inAdvanceEntry.(*logrus.Entry)
+			entry.WithField("route", record.Route).Errorf("unable to append audit record, err: %v", err)
+		}
+	}
+}
+
+func containsString(slice []string, target string) bool {
+	for _, value := range slice {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -25,14 +25,14 @@ func Authenticator() gin.HandlerFunc {
 		if c.GetHeader("Authorization") == "" {
 			respFor401["code"] = respcode.NoAuthorizationInHeader
 			respFor401["message"] = "authorization doesn't exist in header"
-			c.AbortWithStatusJSON(http.StatusUnauthorized, respFor401)
+			abortWithLocalizedJSON(c, http.StatusUnauthorized, respFor401)
 			return
 		}
 
 		if len(strings.Split(c.GetHeader("Authorization"), " ")) != 2 {
 			respFor401["code"] = respcode.InvalidFormatOfAuthorization
 			respFor401["message"] = "invalid data format of Authorization"
-			c.AbortWithStatusJSON(http.StatusUnauthorized, respFor401)
+			abortWithLocalizedJSON(c, http.StatusUnauthorized, respFor401)
 			return
 		}
 
@@ -59,17 +59,17 @@ func Authenticator() gin.HandlerFunc {
 				default:
 					respFor401["message"] = fmt.Sprintf("unexpected error occurs while parsing JWT, err: %v", err)
 				}
-				c.AbortWithStatusJSON(http.StatusUnauthorized, respFor401)
+				abortWithLocalizedJSON(c, http.StatusUnauthorized, respFor401)
 				return
 			default:
 				respFor401["message"] = fmt.Sprintf("error of unexpected type occurs while parsing JWT, err: %v", err)
-				c.AbortWithStatusJSON(http.StatusUnauthorized, respFor401)
+				abortWithLocalizedJSON(c, http.StatusUnauthorized, respFor401)
 				return
 			}
 		default:
 			respFor401["code"] = respcode.UnsupportedAuthorization
 			respFor401["message"] = fmt.Sprintf("%s is an unacceptable authentication method", authType)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, respFor401)
+			abortWithLocalizedJSON(c, http.StatusUnauthorized, respFor401)
 			return
 		}
 
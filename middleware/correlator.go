@@ -3,13 +3,30 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"regexp"
 )
 
-func Correlator() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		xReqId := uuid.New().String()
-		c.Request.Header.Set("X-Request-Id", xReqId)
-		c.Header("X-Request-Id", xReqId)
-		c.Next()
+// X-Request-Id sent from client is accepted only if matched with this regex (add in v.1.0.6)
+var requestIDRegex = regexp.MustCompile("^[0-9a-zA-Z][0-9a-zA-Z._:-]{7,127}$")
+
+type correlator struct {
+	acceptIncoming bool
+}
+
+// if acceptIncoming is true, X-Request-Id sent from upstream (ex. web proxy) is used instead of new one
+// X-Request-Id of internal request (ex. sub request of batch) is always used because it is set in gateway
+func Correlator(acceptIncoming bool) gin.HandlerFunc {
+	return (&correlator{
+		acceptIncoming: acceptIncoming,
+	}).correlate
+}
+
+func (r *correlator) correlate(c *gin.Context) {
+	xReqId := c.GetHeader("X-Request-Id")
+	if !(r.acceptIncoming || IsInternalRequest(c.Request)) || !requestIDRegex.MatchString(xReqId) {
+		xReqId = uuid.New().String()
 	}
+	c.Request.Header.Set("X-Request-Id", xReqId)
+	c.Header("X-Request-Id", xReqId)
+	c.Next()
 }
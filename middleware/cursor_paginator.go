@@ -0,0 +1,114 @@
+// add file in v.1.0.6
+// cursor_paginator.go is file that declare middleware translating cursor in query into offset (start, count) of request
+// & adding next_cursor in response, start & count in query are still accepted for old clients
+
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	gwcode "gateway/tool/code"
+	"gateway/tool/cursor"
+	"gateway/tool/envelope"
+	jwtutil "gateway/tool/jwt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// query parameters not filtering list, which are excluded from scope of cursor
+var nonFilterQueries = map[string]bool{"cursor": true, "start": true, "count": true, "fields": true}
+
+// listField is field of items in response, idField is field of item used to skip items already responded in previous page
+// this middleware must be registered after RequestValidator & before ResponderIfKeyExist, so cache key is formatted with offset
+func CursorPaginator(listField, idField string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		inAdvanceReq, _ := c.Get("Request")
+		reqValue := reflect.ValueOf(inAdvanceReq)
+		if reqValue.Kind() != reflect.Ptr || reqValue.Elem().Kind() != reflect.Struct {
+			return
+		}
+		start, count := reqValue.Elem().FieldByName("Start"), reqValue.Elem().FieldByName("Count")
+		if !start.CanSet() || !count.CanSet() {
+			return
+		}
+
+		scope := cursorScope(c)
+		cur := cursor.Cursor{Start: int(start.Int()), Count: int(count.Int()), Scope: scope}
+		if token := c.Query("cursor"); token != "" {
+			decoded, err := cursor.Decode(token)
+			if err == nil && decoded.Scope != scope {
+				err = errors.New("cursor is not issued for this list, route, user or filter is changed")
+			}
+			if err != nil {
+				abortWithLocalizedJSON(c, http.StatusBadRequest, gin.H{
+					"status": http.StatusBadRequest, "code": gwcode.InvalidCursor, "message": fmt.Sprintf("cursor is invalid, err: %v", err),
+				})
+				return
+			}
+			cur = decoded
+			start.SetInt(int64(cur.Start))
+			count.SetInt(int64(cur.Count))
+		}
+
+		// offset is in redis key, so only cursor (last item of previous page) distinguishes page transformed from same response
+		envelope.AddTransformer(c, "cursor="+c.Query("cursor"), func(resp gin.H) {
+			paginate(resp, listField, idField, cur)
+		})
+	}
+}
+
+// return scope of cursor in this request, which is route with path parameters, user uuid & filter query parameters
+func cursorScope(c *gin.Context) string {
+	inAdvanceClaims, _ := c.Get("Claims")
+	uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
+
+	filter := url.Values{}
+	for key, values := range c.Request.URL.Query() {
+		if !nonFilterQueries[key] {
+			filter[key] = values
+		}
+	}
+	return cursor.Scope(c.Request.Method+" "+c.Request.URL.Path, uuidClaims.UUID, filter.Encode())
+}
+
+// remove items already responded in previous page & set next_cursor (null if page is last one) in response
+// page may have less items than count after removing, so has_more is decided with number of items responded from service
+func paginate(resp gin.H, listField, idField string, cur cursor.Cursor) {
+	items := reflect.ValueOf(resp[listField])
+	if items.Kind() != reflect.Slice {
+		return
+	}
+
+	ids := make([]string, items.Len())
+	for i := range ids {
+		ids[i] = itemID(items.Index(i).Interface(), idField)
+	}
+	resp[listField] = items.Slice(cur.Skip(ids), items.Len()).Interface()
+
+	// page of service having less items than count is last one
+	if cur.Count <= 0 || len(ids) < cur.Count {
+		resp["next_cursor"], resp["has_more"] = nil, false
+		return
+	}
+	next, err := cursor.Encode(cursor.Cursor{Start: cur.Start + len(ids), Count: cur.Count, After: ids[len(ids)-1], Scope: cur.Scope})
+	if err != nil {
+		resp["next_cursor"], resp["has_more"] = nil, false
+		return
+	}
+	resp["next_cursor"], resp["has_more"] = next, true
+}
+
+// return id of item, which is map written in handler or unmarshalled from response cached in redis
+func itemID(item interface{}, idField string) string {
+	switch item := item.(type) {
+	case map[string]interface{}:
+		id, _ := item[idField].(string)
+		return id
+	case gin.H:
+		id, _ := item[idField].(string)
+		return id
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"gateway/tool/envelope"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"sync"
@@ -39,12 +40,10 @@ func (d *dosDetector) detectDos (c *gin.Context) {
 
 	// check ip is blocked
 	if d.rejected[cip] {
-		c.AbortWithStatusJSON(http.StatusTooManyRequests, struct {
-			Status  int    `json:"status"`
-			Message string `json:"message"`
-		}{
-			Status: http.StatusTooManyRequests,
-			Message: "your IP is currently request blocked",
+		envelope.AbortJSON(c, http.StatusTooManyRequests, gin.H{
+			"status":  http.StatusTooManyRequests,
+			"code":    0,
+			"message": "your IP is currently request blocked",
 		})
 		return
 	}
@@ -57,12 +56,10 @@ func (d *dosDetector) detectDos (c *gin.Context) {
 			d.rejected[cip] = false
 			d.mutex.Unlock()
 		})
-		c.AbortWithStatusJSON(http.StatusTooManyRequests, struct {
-			Status  int    `json:"status"`
-			Message string `json:"message"`
-		}{
-			Status:  http.StatusTooManyRequests,
-			Message: "unusual request was detected. Please try again after a minute",
+		envelope.AbortJSON(c, http.StatusTooManyRequests, gin.H{
+			"status":  http.StatusTooManyRequests,
+			"code":    0,
+			"message": "unusual request was detected. Please try again after a minute",
 		})
 		return
 	}
@@ -0,0 +1,34 @@
+// add file in v.1.0.6
+// field_selector.go is file that declare middleware projecting successful response into fields in fields query parameter
+// response is projected just before written, so one response cached in redis is responded in every projection
+
+package middleware
+
+import (
+	"gateway/tool/envelope"
+	"gateway/tool/projection"
+	"github.com/gin-gonic/gin"
+)
+
+// ex) ?fields=title,writer.name,announcements.title -> status, code, message & requested fields are responded
+func FieldSelector() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tree := projection.Parse(c.Query("fields"))
+		if len(tree) == 0 {
+			return
+		}
+
+		envelope.SetProjection(c, "fields="+c.Query("fields"), func(resp gin.H) {
+			for key, value := range resp {
+				if key == "status" || key == "code" || key == "message" {
+					continue
+				}
+				if child, ok := tree[key]; ok {
+					resp[key] = child.Project(value)
+				} else {
+					delete(resp, key)
+				}
+			}
+		})
+	}
+}
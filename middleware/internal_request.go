@@ -0,0 +1,22 @@
+// add file in v.1.0.6
+// internal_request.go is file that declare function marking & checking request dispatched inside gateway (ex. sub request of batch)
+// mark is saved in context of request, so client cannot send request marked as internal request
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+type internalRequestKey struct{}
+
+// return context of request dispatched inside gateway, which already passed global middleware in parent request
+func WithInternalRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, internalRequestKey{}, true)
+}
+
+func IsInternalRequest(r *http.Request) bool {
+	internal, _ := r.Context().Value(internalRequestKey{}).(bool)
+	return internal
+}
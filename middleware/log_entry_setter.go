@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"encoding/json"
+	"gateway/tool/redact"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
@@ -16,9 +17,9 @@ func LogEntrySetter(l *logrus.Logger) gin.HandlerFunc {
 	}).setLogEntry
 }
 
-// Log entry에 path, method, client_ip, X-Request-Id, header 정보 저장
+// Log entry에 path, method, client_ip, X-Request-Id, header, trace_id, span_id 정보 저장
 func (l *logEntrySetter) setLogEntry(c *gin.Context) {
-	headerBytes, _ := json.Marshal(c.Request.Header)
+	headerBytes, _ := json.Marshal(redact.Header(c.Request.Header))
 
 	entry := l.logger.WithFields(logrus.Fields{
 		"path":         c.Request.URL.Path,
@@ -27,6 +28,8 @@ func (l *logEntrySetter) setLogEntry(c *gin.Context) {
 		"X-Request-Id": c.GetHeader("X-Request-Id"),
 		"header":       string(headerBytes),
 		"full_uri":     c.FullPath(),
+		"trace_id":     c.GetString("TraceID"), // add in v.1.0.6
+		"span_id":      c.GetString("SpanID"),  // add in v.1.0.6
 	})
 	c.Set("RequestLogEntry", entry)
 	c.Next()
@@ -8,17 +8,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"gateway/tool/compress"
+	"gateway/tool/envelope"
 	jwtutil "gateway/tool/jwt"
+	"gateway/tool/keyevent"
+	"gateway/tool/redact"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 	systemlog "log"
 	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type redisHandler struct {
@@ -26,23 +32,33 @@ type redisHandler struct {
 	tracer   opentracing.Tracer
 	setTopic string
 	delTopic string
+	flights  *singleflight.Group // coalesce concurrent requests of same redis key in this replica (add in v.1.0.6)
+	lockTTL  time.Duration       // TTL of lock coalescing requests across replicas, 0 disables lock (add in v.1.0.6)
+
+	compressionMinSize int // min size of transformed response precompressed with variant key (add in v.1.0.6)
 }
 
-func RedisHandler(cli *redis.Client, tracer opentracing.Tracer, setTopic, delTopic string) *redisHandler {
+func RedisHandler(cli *redis.Client, tracer opentracing.Tracer, setTopic, delTopic string, lockTTL time.Duration, compressionMinSize int) *redisHandler {
 	return &redisHandler{
 		client:   cli,
 		tracer:   tracer,
 		setTopic: setTopic,
 		delTopic: delTopic,
+		flights:  &singleflight.Group{},
+		lockTTL:  lockTTL,
+
+		compressionMinSize: compressionMinSize,
 	}
 }
 
-func (r *redisHandler) ResponderAndSetEventPublisher(key string, successStatus int) []gin.HandlerFunc {
-	return []gin.HandlerFunc{r.ResponderIfKeyExist(key), r.SetResponseEventPublisher(key, successStatus)}
+// cacheControl is Cache-Control directives of route written in successful response (add in v.1.0.6)
+func (r *redisHandler) ResponderAndSetEventPublisher(key string, successStatus int, cacheControl string) []gin.HandlerFunc {
+	return []gin.HandlerFunc{r.ResponderIfKeyExist(key, cacheControl), r.SetResponseEventPublisher(key, successStatus)}
 }
 
 // response value of redis key if exists instead request to service
-func (r *redisHandler) ResponderIfKeyExist(key string) gin.HandlerFunc {
+// 304 Not Modified is responded if ETag set with value matches If-None-Match header (change in v.1.0.6)
+func (r *redisHandler) ResponderIfKeyExist(key string, cacheControl string) gin.HandlerFunc {
 	if key == "" {
 		systemlog.Fatalln("parameter of ResponderIfKeyExist to get redis key must not be blank string")
 	}
@@ -50,6 +66,7 @@ func (r *redisHandler) ResponderIfKeyExist(key string) gin.HandlerFunc {
 
 	return func(c *gin.Context) {
 		reqID := c.GetHeader("X-Request-Id")
+		envelope.SetCacheControl(c, cacheControl) // add in v.1.0.6
 
 		inAdvanceTopSpan, _ := c.Get("TopSpan")
 		topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
@@ -58,7 +75,7 @@ func (r *redisHandler) ResponderIfKeyExist(key string) gin.HandlerFunc {
 		uuidClaims, _ := inAdvanceClaims.(jwtutil.UUIDClaims)
 
 		inAdvanceReq, _ := c.Get("Request")
-		reqBytes, _ := json.Marshal(inAdvanceReq)
+		reqBytes, _ := redact.Marshal(inAdvanceReq)
 
 		inAdvanceEntry, _ := c.Get("RequestLogEntry")
 		entry, _ := inAdvanceEntry.(*logrus.Entry)
@@ -70,7 +87,7 @@ func (r *redisHandler) ResponderIfKeyExist(key string) gin.HandlerFunc {
 				c.Next()
 				return
 			}
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			envelope.AbortJSON(c, http.StatusInternalServerError, gin.H{
 				"status": http.StatusInternalServerError, "code": 0, "message": err.Error(),
 			})
 			entry.WithFields(logrus.Fields{"status": http.StatusInternalServerError, "code": 0, "message": err.Error(), "request": string(reqBytes)}).Error()
@@ -79,34 +96,95 @@ func (r *redisHandler) ResponderIfKeyExist(key string) gin.HandlerFunc {
 			return
 		}
 
-		value, err := r.client.Get(ctx, redisKey).Result()
+		// get response with gzip precompressed response & ETag set in SetRedisKeyWithResponse handler (change in v.1.0.6)
+		values, err := r.client.MGet(ctx, redisKey, compress.PrecompressedKey(redisKey), envelope.ETagKey(redisKey)).Result()
+		if err == nil && values[0] == nil {
+			err = redis.Nil
+		}
 		if err != nil {
 			err = errors.New(fmt.Sprintf("some error occurs while getting redis value with key, key: %s, err: %v", redisKey, err))
 			redisSpan.SetTag("success", false).LogFields(log.String("key", redisKey), log.Error(err))
 			redisSpan.Finish()
+			// concurrent GET requests of same key share one service call & set event (change in v.1.0.6)
+			if c.Request.Method == http.MethodGet {
+				r.coalesce(c, redisKey, entry.WithFields(logrus.Fields{"user_uuid": uuidClaims.UUID, "request": string(reqBytes)}))
+				return
+			}
+			c.Set("CacheStatus", "miss") // add in v.1.0.6
 			c.Next()
 			return
 		}
 
+		value, _ := values[0].(string)
 		cashedResp := gin.H{}
 		if err := json.Unmarshal([]byte(value), &cashedResp); err != nil {
 			err = errors.New(fmt.Sprintf("some error occurs while unmarshaling value to gin.H, key: %s, value: %s, err: %v", redisKey, value, err))
 			redisSpan.SetTag("success", false).LogFields(log.String("key", redisKey), log.String("value", value), log.Error(err))
 			redisSpan.Finish()
+			c.Set("CacheStatus", "miss") // add in v.1.0.6
 			c.Next()
 			return
 		}
-		respBytes, _ := json.Marshal(cashedResp)
-		redisSpan.SetTag("success", true).LogFields(log.String("key", redisKey), log.String("value", value))
+		respBytes, _ := redact.Marshal(cashedResp)
+		redisSpan.SetTag("success", true).LogFields(log.String("key", redisKey), log.String("value", string(respBytes)))
 		redisSpan.Finish()
 
-		c.AbortWithStatusJSON(int(cashedResp["status"].(float64)), cashedResp)
+		c.Set("CacheStatus", "hit") // add in v.1.0.6
 		entry = entry.WithField("user_uuid", uuidClaims.UUID)
+
+		// response transformed with parameters of request (cursor, fields) has own ETag derived from stored ETag
+		// & own precompressed body set when it is responded first (change in v.1.0.6)
+		etag, _ := values[2].(string)
+		precompressed, hasPrecompressed := values[1].(string)
+		variant := envelope.Variant(c)
+		if variant != "" {
+			hasPrecompressed = false
+			if etag != "" {
+				etag = envelope.VariantETag(etag, variant)
+				precompressed, err = r.client.Get(ctx, compress.PrecompressedKey(variantKey(redisKey, etag))).Result()
+				hasPrecompressed = err == nil
+			}
+		}
+
+		// respond without body if client has response of same ETag (add in v.1.0.6)
+		if etag != "" {
+			if envelope.NotModified(c, etag) {
+				entry.WithFields(logrus.Fields{"status": http.StatusNotModified, "code": cashedResp["code"], "message": cashedResp["message"],
+					"etag": etag, "request": string(reqBytes)}).Info()
+				return
+			}
+			c.Header("ETag", etag)
+		}
+
+		status := int(cashedResp["status"].(float64))
+		if !hasPrecompressed || !envelope.AbortWithPrecompressed(c, status, cashedResp, compress.Gzip, []byte(precompressed)) {
+			envelope.AbortJSON(c, status, cashedResp)
+			if variant != "" && etag != "" {
+				r.setPrecompressedVariant(ctx, variantKey(redisKey, etag), c)
+			}
+		}
 		entry.WithFields(logrus.Fields{"status": cashedResp["status"], "code": cashedResp["code"], "message": cashedResp["message"],
 			"response": string(respBytes), "request": string(reqBytes)}).Info()
 	}
 }
 
+// return redis key of response transformed with variant, which has ETag of variant so that it is not read after response changes
+// it is deleted with key of response in pattern (ex. key.*) or expired (add in v.1.0.6)
+func variantKey(redisKey, variantETag string) string {
+	return fmt.Sprintf("%s.variants.%s", redisKey, strings.Trim(strings.TrimPrefix(variantETag, "W/"), `"`))
+}
+
+// set transformed response written in context as precompressed body of variant key, small response is not compressed
+func (r *redisHandler) setPrecompressedVariant(ctx context.Context, key string, c *gin.Context) {
+	body, ok := envelope.TransformedBody(c)
+	if !ok || len(body) < r.compressionMinSize {
+		return
+	}
+	if compressed, err := compress.Compress(compress.Gzip, body); err == nil {
+		r.client.Set(ctx, compress.PrecompressedKey(key), compressed, time.Minute)
+	}
+}
+
 // publish set redis key event with request payload if success status
 func (r *redisHandler) SetResponseEventPublisher(key string, successStatus int) gin.HandlerFunc {
 	if key == "" {
@@ -129,11 +207,10 @@ func (r *redisHandler) SetResponseEventPublisher(key string, successStatus int)
 		inAdvanceReq, _ := c.Get("Request")
 
 		redisSpan := r.tracer.StartSpan("PublishSetEvent", opentracing.ChildOf(topSpan.Context())).SetTag("X-Request-Id", reqID)
-		status, resp := 0, gin.H{}
+		var status int
 		switch w := c.Writer.(type) {
 		case *ginHResponseWriter:
 			status = w.status
-			resp = w.json
 		default:
 			err := errors.New("unable to get response status code from default response writer")
 			redisSpan.SetTag("success", false).LogFields(log.String("key", key), log.Error(err))
@@ -141,6 +218,15 @@ func (r *redisHandler) SetResponseEventPublisher(key string, successStatus int)
 			return
 		}
 
+		// response written as envelope in handler is published without parsing body (change in v.1.0.6)
+		resp, ok := envelope.FromContext(c)
+		if !ok {
+			err := errors.New("response written in handler is not envelope, so unable to set response in redis")
+			redisSpan.SetTag("success", false).LogFields(log.String("key", key), log.Error(err))
+			redisSpan.Finish()
+			return
+		}
+
 		if status != successStatus {
 			err := errors.New("response status code is not success status code to set response in redis")
 			redisSpan.SetTag("success", false).LogFields(log.String("key", key), log.Error(err))
@@ -155,8 +241,12 @@ func (r *redisHandler) SetResponseEventPublisher(key string, successStatus int)
 			return
 		}
 
-		resp["redis.key"] = redisKey
-		respBytes, _ := json.Marshal(resp)
+		respBytes, err := resp.JSONWith("redis.key", redisKey)
+		if err != nil {
+			redisSpan.SetTag("success", false).LogFields(log.String("key", redisKey), log.Error(err))
+			redisSpan.Finish()
+			return
+		}
 		result, err := r.client.Publish(ctx, r.setTopic, string(respBytes)).Result()
 
 		if err != nil {
@@ -164,7 +254,8 @@ func (r *redisHandler) SetResponseEventPublisher(key string, successStatus int)
 		} else {
 			redisSpan.SetTag("success", true)
 		}
-		redisSpan.LogFields(log.String("topic", r.setTopic), log.String("msg", string(respBytes)),
+		redactedBytes, _ := redact.Marshal(resp.Map())
+		redisSpan.LogFields(log.String("topic", r.setTopic), log.String("msg", string(redactedBytes)),
 			log.String("key", redisKey), log.Int64("result", result), log.Error(err))
 		redisSpan.Finish()
 		return
@@ -230,6 +321,17 @@ func (r *redisHandler) DeleteKeyEventPublisher(keys[] string, successStatus int)
 				redisSpan.Finish()
 				return
 			}
+
+			// request id & user uuid are used in PublishStudentEvent to dedup & find student of outing, which are published
+			// on event topic so that message of delete topic is not changed for gateway before v.1.0.6 (add in v.1.0.6)
+			eventTopic := keyevent.Topic(r.delTopic)
+			_, err = r.client.Publish(ctx, eventTopic, keyevent.Message(redisKey, reqID, uuidClaims.UUID)).Result()
+			if err != nil {
+				redisSpan.SetTag("success", false).LogFields(log.String("topic", eventTopic),
+					log.String("key", redisKey), log.Error(err))
+				redisSpan.Finish()
+				return
+			}
 		}
 
 		redisSpan.LogFields(log.String("topic", r.delTopic), log.Object("keys", redisKeys))
@@ -239,6 +341,13 @@ func (r *redisHandler) DeleteKeyEventPublisher(keys[] string, successStatus int)
 }
 
 func (r *redisHandler) formatKeyWithRequest(key string, c *gin.Context, req interface{}, claims jwtutil.UUIDClaims) (redisKey string, err error) {
+	return FormatRedisKey(key, c, req, claims)
+}
+
+// return redis key formatted with uri parameter, field of request entity & token uuid, ex) $student_uuid, $Start, $TokenUUID
+// blank key is returned if $student_uuid or $writer_uuid is not uuid in token, which means response must not be cached
+// it is used also in handler reading cache of other API, so that key is not formatted differently (change in v.1.0.6)
+func FormatRedisKey(key string, c *gin.Context, req interface{}, claims jwtutil.UUIDClaims) (redisKey string, err error) {
 	var reqValue reflect.Value
 	if req != nil {
 		reqValue = reflect.ValueOf(req).Elem()
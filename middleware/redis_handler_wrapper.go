@@ -8,24 +8,35 @@ import (
 	"net/http"
 )
 
+// redis keys of response cache read also in handler aggregating APIs (dashboard), which are formatted with FormatRedisKey (add in v.1.0.6)
+const (
+	StudentOutingsKey    = "students.$student_uuid.outings.start.$Start.count.$Count"
+	OutingsWithFilterKey = "outings.filter.start.$Start.count.$Count.status.$Status.grade.$Grade.group.$Group.floor.$Floor.start_time.$StartTime.end_time.$EndTime"
+	TimeTableKey         = "students.$TokenUUID.timetable.years.$Year.months.$Month.days.$Day.count.$Count"
+	AnnouncementCheckKey = "students.$student_uuid.announcement-check"
+)
+
 func (r *redisHandler) CreateOuting() []gin.HandlerFunc {
 	redisDelKeys := []string{"students.$TokenUUID.outings", "outings.filter"}
 	return []gin.HandlerFunc{r.DeleteKeyEventPublisher(redisDelKeys, http.StatusCreated)}
 }
 
 func (r *redisHandler) GetStudentOutings() []gin.HandlerFunc {
-	redisSetKey := "students.$student_uuid.outings.start.$Start.count.$Count"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	redisSetKey := StudentOutingsKey
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) GetOutingInform() []gin.HandlerFunc {
 	redisSetKey := "outings.$outing_uuid"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) GetCardAboutOuting() []gin.HandlerFunc {
 	redisSetKey := "outings.$outing_uuid.card"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) TakeActionInOuting() []gin.HandlerFunc {
@@ -34,8 +45,9 @@ func (r *redisHandler) TakeActionInOuting() []gin.HandlerFunc {
 }
 
 func (r *redisHandler) GetOutingWithFilter() []gin.HandlerFunc {
-	redisSetKey := "outings.filter.start.$Start.count.$Count.status.$Status.grade.$Grade.group.$Group.floor.$Floor.start_time.$StartTime.end_time.$EndTime"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	redisSetKey := OutingsWithFilterKey
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) ModifyOuting() []gin.HandlerFunc {
@@ -50,12 +62,14 @@ func (r *redisHandler) CreateSchedule() []gin.HandlerFunc {
 
 func (r *redisHandler) GetSchedule() []gin.HandlerFunc {
 	redisSetKey := "schedules.years.$Year.months.$Month"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, max-age=60" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) GetTimeTable() []gin.HandlerFunc {
-	redisSetKey := "students.$TokenUUID.timetable.years.$Year.months.$Month.days.$Day.count.$Count"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	redisSetKey := TimeTableKey
+	cacheControl := "private, max-age=300" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) UpdateSchedule() []gin.HandlerFunc {
@@ -69,19 +83,22 @@ func (r *redisHandler) DeleteSchedule() []gin.HandlerFunc {
 }
 
 func (r *redisHandler) CreateAnnouncement() []gin.HandlerFunc {
-	redisDelKeys := []string{"announcements.uuid.*.types.$Type", "students.*.announcement-check", "writers.$TokenUUID.announcements"}
+	redisDelKeys := []string{"announcements.uuid.*.types.$Type", "students.*.announcement-check", "writers.$TokenUUID.announcements",
+		"announcements.types.$Type.grades.$TargetGrade.groups.$TargetGroup"} // add key to notify target students in v.1.0.6
 	return []gin.HandlerFunc{r.DeleteKeyEventPublisher(redisDelKeys, http.StatusCreated)}
 }
 
 func (r *redisHandler) GetAnnouncements() []gin.HandlerFunc {
 	redisSetKey := "announcements.uuid.$TokenUUID.types.$type.start.$Start.count.$Count"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) GetAnnouncementDetail() []gin.HandlerFunc {
 	redisDelKeys := []string{"students.$TokenUUID.announcement-check", "announcements.uuid.$TokenUUID.types.{announcements.$announcement_uuid.type}", "writers.$TokenUUID.announcements"}
 	redisSetKey := "announcements.$announcement_uuid"
-	return append([]gin.HandlerFunc{r.DeleteKeyEventPublisher(redisDelKeys, http.StatusOK)}, r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)...)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return append([]gin.HandlerFunc{r.DeleteKeyEventPublisher(redisDelKeys, http.StatusOK)}, r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)...)
 }
 
 func (r *redisHandler) UpdateAnnouncement() []gin.HandlerFunc {
@@ -97,16 +114,19 @@ func (r *redisHandler) DeleteAnnouncement() []gin.HandlerFunc {
 }
 
 func (r *redisHandler) CheckAnnouncement() []gin.HandlerFunc {
-	redisSetKey := "students.$student_uuid.announcement-check"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	redisSetKey := AnnouncementCheckKey
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) SearchAnnouncements() []gin.HandlerFunc {
 	redisSetKey := "announcements.uuid.$TokenUUID.types.$type.query.$search_query.start.$Start.count.$Count"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
 
 func (r *redisHandler) GetMyAnnouncements() []gin.HandlerFunc {
 	redisSetKey := "writers.$writer_uuid.announcements.start.$Start.count.$Count"
-	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK)
+	cacheControl := "private, no-cache" // add in v.1.0.6
+	return r.ResponderAndSetEventPublisher(redisSetKey, http.StatusOK, cacheControl)
 }
@@ -0,0 +1,74 @@
+// add file in v.1.0.6
+// request_binder.go is file that declare function binding request into request entity from sources in struct tags
+// (uri, header, form, json) & setting value declared in default tag into field not set in request (in entity/registry)
+
+package middleware
+
+import (
+	"fmt"
+	entityregistry "gateway/entity/registry"
+	"gateway/tool/codec"
+	code "gateway/utils/code/golang"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// bind request into req from all sources of request entity, return code & message for 400 response if failed
+func bindRequest(c *gin.Context, reqEntity entityregistry.Entity, req interface{}) (_code int, msg string, ok bool) {
+	if reqEntity.BindsFrom(entityregistry.BindURI) {
+		if err := c.ShouldBindUri(req); err != nil {
+			return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind uri in request into golang struct, err: %v", err), false
+		}
+	}
+	if reqEntity.BindsFrom(entityregistry.BindHeader) {
+		if err := c.ShouldBindHeader(req); err != nil {
+			return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind header in request into golang struct, err: %v", err), false
+		}
+	}
+	if reqEntity.BindsFrom(entityregistry.BindQuery) {
+		if err := c.ShouldBindQuery(req); err != nil {
+			return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind query parameter in request into golang struct, err: %v", err), false
+		}
+	}
+	if reqEntity.BindsFrom(entityregistry.BindBody) {
+		switch c.ContentType() {
+		case "multipart/form-data":
+			if err := c.ShouldBindWith(req, binding.FormMultipart); err != nil {
+				return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind multipart request into golang struct, err: %v", err), false
+			}
+		case "application/json":
+			if err := c.ShouldBindJSON(req); err != nil {
+				return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind json request into golang struct, err: %v", err), false
+			}
+		case codec.MIMEMsgPack, codec.MIMEMsgPack2, codec.MIMEProtobuf:
+			// body of msgpack, protobuf is bound in same way as json after converted into json (add in v.1.0.6)
+			if err := bindJSONConvertedBody(c, req); err != nil {
+				return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind %s request into golang struct, err: %v", c.ContentType(), err), false
+			}
+		case "":
+			if err := c.ShouldBindWith(req, binding.Form); err != nil {
+				return code.FailToBindRequestToStruct, fmt.Sprintf("failed to bind request into golang struct, err: %v", err), false
+			}
+		default:
+			return code.UnsupportedContentType, fmt.Sprintf("%s is an unsupported content type", c.ContentType()), false
+		}
+	}
+
+	if err := entityregistry.SetDefaultValues(req); err != nil {
+		return code.FailToBindRequestToStruct, fmt.Sprintf("failed to set default value of request, err: %v", err), false
+	}
+	return 0, "", true
+}
+
+// bind body of content type (msgpack, protobuf) into req with json binding after converting body into json
+func bindJSONConvertedBody(c *gin.Context, req interface{}) error {
+	body, err := c.GetRawData()
+	if err != nil {
+		return err
+	}
+	converted, err := codec.ToJSON(c.ContentType(), body)
+	if err != nil {
+		return err
+	}
+	return binding.JSON.BindBody(converted, req)
+}
@@ -0,0 +1,131 @@
+// add file in v.1.0.6
+// request_coalescer.go is file that declare method of redisHandler coalescing concurrent GET requests of same redis key
+// requests in this replica share one call with singleflight & replicas share one call with short redis lock (distributed)
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"gateway/tool/envelope"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+// interval to check if response is set in redis while other replica holds lock
+const coalescingPollInterval = time.Millisecond * 50
+
+// delete lock only if lock is still held by this request (value is X-Request-Id), lock may be expired & acquired by other
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// response of request which called service, shared with requests waiting for same redis key
+type coalescedResponse struct {
+	status int
+	resp   gin.H
+}
+
+// return redis key of lock held while calling service to set response in redis key
+func lockKey(key string) string {
+	return key + ".lock"
+}
+
+// run rest handlers (service call & set event publisher) only in one of concurrent requests of same redis key
+// other requests respond with response of that request, or run rest handlers by themselves if that request failed
+func (r *redisHandler) coalesce(c *gin.Context, redisKey string, entry *logrus.Entry) {
+	leader := false
+	result, _, _ := r.flights.Do(redisKey, func() (interface{}, error) {
+		leader = true
+		return r.callOnce(c, redisKey), nil
+	})
+	if leader {
+		return
+	}
+
+	shared, _ := result.(*coalescedResponse)
+	if shared == nil {
+		c.Set("CacheStatus", "miss")
+		c.Next()
+		return
+	}
+	c.Set("CacheStatus", "coalesced")
+	envelope.AbortJSON(c, shared.status, shared.resp)
+	entry.WithFields(logrus.Fields{"status": shared.status, "code": shared.resp["code"], "message": shared.resp["message"]}).Info()
+}
+
+// call service after acquiring redis lock, or respond with response set in redis by other replica holding lock
+// nil is returned if service call fails, so waiting requests don't share failed response
+func (r *redisHandler) callOnce(c *gin.Context, redisKey string) *coalescedResponse {
+	if r.lockTTL > 0 {
+		token := c.GetHeader("X-Request-Id")
+		acquired, err := r.client.SetNX(c.Request.Context(), lockKey(redisKey), token, r.lockTTL).Result()
+		switch {
+		case err != nil:
+			break
+		case !acquired:
+			if resp, ok := r.waitForResponse(c.Request.Context(), redisKey); ok {
+				status := int(resp["status"].(float64))
+				c.Set("CacheStatus", "hit")
+				envelope.AbortJSON(c, status, resp)
+				return &coalescedResponse{status: status, resp: resp}
+			}
+		default:
+			// lock is kept until expired if succeed, because response is set in redis asynchronously with set event
+			defer func() {
+				if status := c.Writer.Status(); status < http.StatusOK || status >= http.StatusMultipleChoices {
+					releaseLockScript.Run(context.Background(), r.client, []string{lockKey(redisKey)}, token)
+				}
+			}()
+		}
+	}
+
+	c.Set("CacheStatus", "miss")
+	c.Next()
+
+	e, ok := envelope.FromContext(c)
+	if status := c.Writer.Status(); !ok || status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return nil
+	}
+	return &coalescedResponse{status: e.Status, resp: e.Map()}
+}
+
+// wait until response is set in redis key by replica holding lock, false is returned if lock is released or expired before
+func (r *redisHandler) waitForResponse(ctx context.Context, redisKey string) (gin.H, bool) {
+	ticker := time.NewTicker(coalescingPollInterval)
+	defer ticker.Stop()
+	timeout := time.After(r.lockTTL)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-timeout:
+			return nil, false
+		case <-ticker.C:
+		}
+
+		values, err := r.client.MGet(ctx, redisKey, lockKey(redisKey)).Result()
+		if err != nil {
+			return nil, false
+		}
+		if value, ok := values[0].(string); ok {
+			resp := gin.H{}
+			if err := json.Unmarshal([]byte(value), &resp); err != nil {
+				return nil, false
+			}
+			if _, ok := resp["status"].(float64); !ok {
+				return nil, false
+			}
+			return resp, true
+		}
+		if values[1] == nil {
+			return nil, false
+		}
+	}
+}
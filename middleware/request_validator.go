@@ -5,16 +5,14 @@ package middleware
 
 import (
 	"fmt"
-	"gateway/entity"
 	entityregistry "gateway/entity/registry"
+	entityvalidator "gateway/entity/validator"
+	"gateway/tool/i18n"
 	code "gateway/utils/code/golang"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"log"
 	"net/http"
-	"reflect"
-	"runtime"
-	"strings"
 )
 
 // create all closure from this global struct method
@@ -24,130 +22,46 @@ type requestValidator struct {
 	validator *validator.Validate
 }
 
-func RequestValidator(v *validator.Validate, h gin.HandlerFunc) gin.HandlerFunc {
+// return middleware binding & validating request entity bound with handler in route registration (change in v.1.0.6)
+// request entity is found in bindings generated in handler package, not with name of handler
+func RequestValidator(v *validator.Validate, reqEntity entityregistry.Entity) gin.HandlerFunc {
 	if globalValidator == nil || globalValidator.validator != v {
 		globalValidator = &requestValidator{v}
 	}
 
-	return globalValidator.RequestValidator(h)
+	return globalValidator.RequestValidator(reqEntity)
 }
 
-func (r *requestValidator) RequestValidator(h gin.HandlerFunc) gin.HandlerFunc {
-	// EX) gateway/handler.(*_default).CreateNewStudent-fm
-	fNames := strings.Split(runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name(), ".")
-	fName := strings.TrimSuffix(fNames[2], "-fm")
+func (r *requestValidator) RequestValidator(reqEntity entityregistry.Entity) gin.HandlerFunc {
+	if err := entityregistry.SetDefaultValues(reqEntity.New()); err != nil {
+		log.Fatalf("default tag of request entity is invalid, entity name: %s, err: %v\n", reqEntity.Name, err)
+	}
 
 	return func(c *gin.Context) {
-		req, ok := entityregistry.GetInstance(fName + "Request")
-		if !ok {
-			c.Next()
-			return
-		}
+		req := reqEntity.New()
 		respFor400 := gin.H{
 			"status":  http.StatusBadRequest,
 			"code":    0,
 			"message": "",
 		}
 
-		switch req := req.(type) {
-		case *entity.GetTimeTableRequest:
-			if err := c.ShouldBindUri(req); err != nil {
-				respFor400["code"] = code.FailToBindRequestToStruct
-				respFor400["message"] = fmt.Sprintf("failed to bind uri in request into golang struct, err: %v", err)
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-				return
-			}
-			if err := c.ShouldBindQuery(req); err != nil {
-				respFor400["code"] = code.FailToBindRequestToStruct
-				respFor400["message"] = fmt.Sprintf("failed to bind uri in request into golang struct, err: %v", err)
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-				return
-			}
-			if req.Count == 0 {
-				req.Count = 1
-			}
-		case *entity.GetScheduleRequest, *entity.GetUnsignedStudentWithAuthCodeRequest:
-			if err := c.ShouldBindUri(req); err != nil {
-				respFor400["code"] = code.FailToBindRequestToStruct
-				respFor400["message"] = fmt.Sprintf("failed to bind uri in request into golang struct, err: %v", err)
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-				return
-			}
-		case *entity.GetClubsSortByUpdateTimeRequest, *entity.GetRecruitmentsSortByCreateTimeRequest, *entity.GetStudentOutingsRequest,
-			*entity.GetAnnouncementsRequest, *entity.GetPlaceWithNaverOpenAPIRequest,
-			*entity.GetStudentUUIDsWithInformRequest, *entity.GetTeacherUUIDsWithInformRequest, *entity.GetParentUUIDsWithInformRequest,
-			*entity.GetMyAnnouncementsRequest, *entity.SearchAnnouncementsRequest, *entity.SendJoinSMSToUnsignedStudentsRequest:
-				if err := c.ShouldBindQuery(req); err != nil {
-					respFor400["code"] = code.FailToBindRequestToStruct
-					respFor400["message"] = fmt.Sprintf("failed to bind query parameter in request into golang struct, err: %v", err)
-					c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-					return
-				}
-
-				// set count field value to 10 if 0
-				if countValue := reflect.ValueOf(req).Elem().FieldByName("Count"); countValue.IsValid() {
-					switch countValue.Type().Kind() {
-					case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-						reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-							if countValue.Int() == 0 {
-								countValue.SetInt(10)
-							}
-					}
-				}
-		case *entity.GetOutingWithFilterRequest:
-			if err := c.ShouldBindQuery(req); err != nil {
-				respFor400["code"] = code.FailToBindRequestToStruct
-				respFor400["message"] = fmt.Sprintf("failed to bind query parameter in request into golang struct, err: %v", err)
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-				return
-			}
-		default:
-			switch c.ContentType() {
-			case "multipart/form-data":
-				if err := c.ShouldBindWith(req, binding.FormMultipart); err != nil {
-					respFor400["code"] = code.FailToBindRequestToStruct
-					respFor400["message"] = fmt.Sprintf("failed to bind multipart request into golang struct, err: %v", err)
-					c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-					return
-				}
-			case "application/json":
-				if err := c.ShouldBindJSON(req); err != nil {
-					respFor400["code"] = code.FailToBindRequestToStruct
-					respFor400["message"] = fmt.Sprintf("failed to bind json request into golang struct, err: %v", err)
-					c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-					return
-				}
-			case "":
-				if err := c.ShouldBindWith(req, binding.Form); err != nil {
-					respFor400["code"] = code.FailToBindRequestToStruct
-					respFor400["message"] = fmt.Sprintf("failed to bind request into golang struct, err: %v", err)
-					c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-					return
-				}
-				break
-			default:
-				respFor400["code"] = code.UnsupportedContentType
-				respFor400["message"] = fmt.Sprintf("%s is an unsupported content type", c.ContentType())
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-				return
-			}
+		// bind request from sources declared in struct tags of request entity (change from type switch in v.1.0.6)
+		if _code, msg, ok := bindRequest(c, reqEntity, req); !ok {
+			respFor400["code"] = _code
+			respFor400["message"] = msg
+			abortWithLocalizedJSON(c, http.StatusBadRequest, respFor400)
+			return
 		}
 
 		if err := r.validator.Struct(req); err != nil {
 			respFor400["code"] = code.IntegrityInvalidRequest
 			respFor400["message"] = fmt.Sprintf("request is not valid for integrity constraints, err: %v", err)
-			c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
-			return
-		}
-
-		switch req.(type) {
-		case **entity.GetStudentUUIDsWithInformRequest, **entity.GetTeacherUUIDsWithInformRequest, **entity.GetParentUUIDsWithInformRequest:
-			emptyValue := reflect.New(reflect.TypeOf(req).Elem()).Elem().Interface()
-			if reflect.DeepEqual(reflect.ValueOf(req).Elem().Interface(), emptyValue) {
-				respFor400["code"] = code.IntegrityInvalidRequest
-				respFor400["message"] = "you must set up at least one parameter"
-				c.AbortWithStatusJSON(http.StatusBadRequest, respFor400)
+			// respond field errors with message in language of Accept-Language header (add in v.1.0.6)
+			if fieldErrs, ok := entityvalidator.FieldErrors(err, i18n.Negotiate(c.GetHeader("Accept-Language"))); ok {
+				respFor400["errors"] = fieldErrs
 			}
+			abortWithLocalizedJSON(c, http.StatusBadRequest, respFor400)
+			return
 		}
 
 		c.Set("Request", req)
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"encoding/json"
+	entityregistry "gateway/entity/registry"
+	code "gateway/utils/code/golang"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// request entity bound from path parameter, query parameter & json body in test
+type testRequest struct {
+	UUID  string `uri:"uuid" validate:"required"`
+	Count int    `form:"count" default:"10"`
+	Name  string `json:"name" validate:"required,max=10"`
+}
+
+var testRequestEntity = entityregistry.Entity{
+	Name:     "testRequest",
+	Type:     reflect.TypeOf(testRequest{}),
+	Bindings: entityregistry.BindURI | entityregistry.BindQuery | entityregistry.BindBody,
+}
+
+// send request to router running RequestValidator with test request entity, return response & request bound in handler
+func serveWithRequestValidator(t *testing.T, contentType, target, body string) (*httptest.ResponseRecorder, *testRequest) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var bound *testRequest
+	router.POST("/tests/:uuid", RequestValidator(validator.New(), testRequestEntity), func(c *gin.Context) {
+		bound, _ = c.MustGet("Request").(*testRequest)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, bound
+}
+
+// return code in body of response responded by RequestValidator
+func responseCode(t *testing.T, w *httptest.ResponseRecorder) int {
+	resp := struct {
+		Code int `json:"code"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unable to unmarshal response, body: %s, err: %v", w.Body.String(), err)
+	}
+	return resp.Code
+}
+
+// check if request entity is bound from all sources in bindings & default value is set in field not sent (add in v.1.0.6)
+func TestRequestValidatorBindsRequestEntity(t *testing.T) {
+	w, bound := serveWithRequestValidator(t, "application/json", "/tests/uuid-1", `{"name": "gateway"}`)
+	if w.Code != http.StatusOK || bound == nil {
+		t.Fatalf("request must be bound & passed to handler, status: %d, body: %s", w.Code, w.Body.String())
+	}
+	if expected := (testRequest{UUID: "uuid-1", Count: 10, Name: "gateway"}); *bound != expected {
+		t.Errorf("request is bound wrongly, expected: %+v, actual: %+v", expected, *bound)
+	}
+
+	_, bound = serveWithRequestValidator(t, "application/json", "/tests/uuid-1?count=3", `{"name": "gateway"}`)
+	if bound == nil || bound.Count != 3 {
+		t.Errorf("query parameter sent in request must be bound instead of default value, request: %+v", bound)
+	}
+}
+
+// check if request not satisfying validate tag is rejected with 400 before handler
+func TestRequestValidatorRejectsInvalidRequest(t *testing.T) {
+	w, bound := serveWithRequestValidator(t, "application/json", "/tests/uuid-1", `{"name": "name longer than max"}`)
+	if w.Code != http.StatusBadRequest || bound != nil {
+		t.Fatalf("invalid request must be rejected before handler, status: %d", w.Code)
+	}
+	if _code := responseCode(t, w); _code != code.IntegrityInvalidRequest {
+		t.Errorf("code of invalid request must be %d, code: %d", code.IntegrityInvalidRequest, _code)
+	}
+}
+
+// check if request unable to be bound is rejected with 400 before handler
+func TestRequestValidatorRejectsUnboundRequest(t *testing.T) {
+	for _, tc := range []struct {
+		contentType, target, body string
+		code                      int
+	}{
+		{contentType: "application/json", target: "/tests/uuid-1?count=ten", body: `{"name": "gateway"}`, code: code.FailToBindRequestToStruct},
+		{contentType: "application/json", target: "/tests/uuid-1", body: `{"name": `, code: code.FailToBindRequestToStruct},
+		{contentType: "text/plain", target: "/tests/uuid-1", body: "gateway", code: code.UnsupportedContentType},
+	} {
+		w, bound := serveWithRequestValidator(t, tc.contentType, tc.target, tc.body)
+		if w.Code != http.StatusBadRequest || bound != nil {
+			t.Errorf("request unable to be bound must be rejected before handler, target: %s, status: %d", tc.target, w.Code)
+			continue
+		}
+		if _code := responseCode(t, w); _code != tc.code {
+			t.Errorf("code of request unable to be bound is wrong, target: %s, expected: %d, actual: %d", tc.target, tc.code, _code)
+		}
+	}
+}
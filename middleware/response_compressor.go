@@ -0,0 +1,118 @@
+// add file in v.1.0.6
+// response_compressor.go is file that declare middleware compressing response body with encoding in Accept-Encoding header
+// body is buffered & compressed after handler only if content type is in allowlist, other responses (stream, etc ...) pass through
+
+package middleware
+
+import (
+	"bytes"
+	"gateway/tool/compress"
+	"github.com/gin-gonic/gin"
+	"log"
+	"mime"
+	"net/http"
+)
+
+type responseCompressor struct {
+	minSize      int             // body smaller than this size is not compressed
+	contentTypes map[string]bool // media types of response to compress
+}
+
+// this middleware must be registered before GinHResponseWriter, so writer of GinHResponseWriter gets uncompressed body
+func ResponseCompressor(minSize int, contentTypes []string) gin.HandlerFunc {
+	compressor := &responseCompressor{minSize: minSize, contentTypes: map[string]bool{}}
+	for _, contentType := range contentTypes {
+		compressor.contentTypes[contentType] = true
+	}
+	return compressor.compressResponse
+}
+
+func (r *responseCompressor) compressResponse(c *gin.Context) {
+	encoding := compress.Negotiate(c.GetHeader("Accept-Encoding"))
+	if encoding == "" || c.Request.Method == http.MethodHead {
+		c.Next()
+		return
+	}
+
+	w := &compressWriter{ResponseWriter: c.Writer, compressor: r, encoding: encoding}
+	c.Writer = w
+	c.Next()
+	w.finish()
+}
+
+// compressWriter decides whether to compress in first write with Content-Type & Content-Encoding header set in handler
+type compressWriter struct {
+	gin.ResponseWriter
+	compressor *responseCompressor
+	encoding   string
+	decided    bool
+	buffering  bool // true if body is buffered to be compressed in finish
+	buf        bytes.Buffer
+}
+
+// decide to buffer body if content type is in allowlist & body is not already compressed (ex. precompressed cache)
+func (w *compressWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if !w.compressor.contentTypes[mediaType] || w.Header().Get("Content-Encoding") != "" {
+		return
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	w.buffering = true
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.buf.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// header is written in finish if body is buffered
+func (w *compressWriter) WriteHeaderNow() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// buffered body is written at once in finish, so flush is ignored while buffering
+func (w *compressWriter) Flush() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// write buffered body, which is compressed if it is not smaller than min size
+func (w *compressWriter) finish() {
+	if !w.buffering {
+		return
+	}
+
+	body := w.buf.Bytes()
+	if len(body) >= w.compressor.minSize {
+		compressed, err := compress.Compress(w.encoding, body)
+		if err != nil {
+			log.Printf("unable to compress response with %s, err: %v\n", w.encoding, err)
+		} else {
+			w.Header().Set("Content-Encoding", w.encoding)
+			w.Header().Del("Content-Length")
+			body = compressed
+		}
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
@@ -0,0 +1,19 @@
+// add file in v.1.0.6
+// response_localizer.go is file that declare function aborting request with response of message localized in catalog
+// message of response is replaced with message of code in language negotiated with Accept-Language header
+
+package middleware
+
+import (
+	"gateway/tool/envelope"
+	"gateway/tool/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// abort request with resp whose message is localized, message is not replaced if code & status are not in catalog
+func abortWithLocalizedJSON(c *gin.Context, status int, resp gin.H) {
+	_code, _ := resp["code"].(int)
+	msg, _ := resp["message"].(string)
+	resp["message"] = i18n.Localize(c.GetHeader("Accept-Language"), status, _code, msg)
+	envelope.AbortJSON(c, status, resp)
+}
@@ -0,0 +1,32 @@
+// add file in v.1.0.6
+// security_exemption.go is file that declare requests exempted from SecurityFilter, which are opened directly in browser
+// so they can't send Request-Security header (ex. parent action link in SMS, Swagger UI), each is authorized in other way
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityExemption returns true if request is exempted from SecurityFilter, full path & params of route are set in context
+type SecurityExemption func(c *gin.Context) bool
+
+// return exemption of routes with full path in gin format for every method, ex) /swagger
+func ExemptRoutes(fullPaths ...string) SecurityExemption {
+	exempted := map[string]bool{}
+	for _, path := range fullPaths {
+		exempted[path] = true
+	}
+	return func(c *gin.Context) bool {
+		return exempted[c.FullPath()]
+	}
+}
+
+// return exemption of parent action link (parent-approve, parent-reject action of route with full path) having token
+// parent opening link doesn't have app, so request is authorized with signed single-use token in link instead
+func ExemptParentActionLink(fullPath string) SecurityExemption {
+	return func(c *gin.Context) bool {
+		action := c.Param("action")
+		return c.FullPath() == fullPath && (action == "parent-approve" || action == "parent-reject") && c.Query("token") != ""
+	}
+}
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"fmt"
+	"gateway/tool/envelope"
+	"gateway/tool/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/mervick/aes-everywhere/go/aes256"
 	"log"
@@ -12,6 +14,7 @@ import (
 )
 
 type securityFilter struct {
+	exemptions        []SecurityExemption // requests passing without Request-Security header (add in v.1.0.6)
 	basePlain         string
 	passPhrase        string
 	filteredSecurity  map[string]bool
@@ -20,7 +23,8 @@ type securityFilter struct {
 	mutex             *sync.Mutex
 }
 
-func SecurityFilter() gin.HandlerFunc {
+// exemptions are checked with route matched before this middleware, so it must be registered with Use of engine (change in v.1.0.6)
+func SecurityFilter(exemptions ...SecurityExemption) gin.HandlerFunc {
 	basePlain := os.Getenv("SECURITY_BASE_PLAIN")
 	if basePlain == "" {
 		log.Fatal("please set SECURITY_BASE_PLAIN in environment variable")
@@ -31,6 +35,7 @@ func SecurityFilter() gin.HandlerFunc {
 	}
 
 	return (&securityFilter{
+		exemptions:        exemptions,
 		basePlain:         basePlain,
 		passPhrase:        passPhrase,
 		filteredSecurity:  map[string]bool{},
@@ -41,27 +46,41 @@ func SecurityFilter() gin.HandlerFunc {
 }
 
 func (s *securityFilter) filterSecurity(c *gin.Context) {
-	respFor407 := struct {
-		Status  int    `json:"status"`
-		Message string `json:"message"`
-	}{
-		Status: http.StatusProxyAuthRequired,
-		Message: "please send the request through the proxy",
+	// response written as envelope having code field (change in v.1.0.6)
+	respFor407 := gin.H{
+		"status": http.StatusProxyAuthRequired,
+		"code":   0,
+		// message localized with Accept-Language header (change in v.1.0.6)
+		"message": i18n.Localize(c.GetHeader("Accept-Language"), http.StatusProxyAuthRequired, 0, "please send the request through the proxy"),
+	}
+
+	// internal request (ex. sub request of batch) was already filtered in parent request (add in v.1.0.6)
+	if IsInternalRequest(c.Request) {
+		c.Next()
+		return
+	}
+
+	// request opened directly in browser is authorized in handler instead (add in v.1.0.6)
+	for _, exempted := range s.exemptions {
+		if exempted(c) {
+			c.Next()
+			return
+		}
 	}
 
 	security := c.GetHeader("Request-Security")
 	if security == "" {
-		c.AbortWithStatusJSON(http.StatusProxyAuthRequired, respFor407)
+		envelope.AbortJSON(c, http.StatusProxyAuthRequired, respFor407)
 		return
 	}
 
 	if s.filteredSecurity[security] {
-		c.AbortWithStatusJSON(http.StatusProxyAuthRequired, respFor407)
+		envelope.AbortJSON(c, http.StatusProxyAuthRequired, respFor407)
 		return
 	}
 
 	if s.onceUsedSecurity[security] {
-		c.AbortWithStatusJSON(http.StatusProxyAuthRequired, respFor407)
+		envelope.AbortJSON(c, http.StatusProxyAuthRequired, respFor407)
 		return
 	}
 
@@ -72,7 +91,7 @@ func (s *securityFilter) filterSecurity(c *gin.Context) {
 
 	if decrypted == "" || !s.basePlainTemplate.MatchString(decrypted) {
 		s.filteredSecurity[security] = true
-		c.AbortWithStatusJSON(http.StatusProxyAuthRequired, respFor407)
+		envelope.AbortJSON(c, http.StatusProxyAuthRequired, respFor407)
 		return
 	}
 
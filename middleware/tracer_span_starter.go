@@ -5,11 +5,11 @@ package middleware
 
 import (
 	"fmt"
+	"gateway/tool/envelope"
+	"gateway/tool/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
-	systemlog "log"
-	"net/http"
 )
 
 type tracerSpanStarter struct {
@@ -25,29 +25,31 @@ func TracerSpanStarter(t opentracing.Tracer) gin.HandlerFunc {
 // start, end top span of tracer & set log as response gotten by ResponseWriter
 func (s *tracerSpanStarter) startTracerSpan(c *gin.Context) {
 	reqID := c.GetHeader("X-Request-Id")
-	topSpan := s.tracer.StartSpan(fmt.Sprintf("%s %s", c.Request.Method, c.FullPath())).SetTag("X-Request-Id", reqID)
+
+	// continue trace of client if span context exists in traceparent, tracestate header (add in v.1.0.6)
+	var startOpts []opentracing.StartSpanOption
+	if clientSpanCtx, err := s.tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(c.Request.Header)); err == nil {
+		startOpts = append(startOpts, opentracing.ChildOf(clientSpanCtx))
+	}
+	topSpan := s.tracer.StartSpan(fmt.Sprintf("%s %s", c.Request.Method, c.FullPath()), startOpts...).SetTag("X-Request-Id", reqID)
 	c.Set("TopSpan", topSpan)
 
+	// set trace id & span id of top span to correlate log, error response with trace (add in v.1.0.6)
+	traceID, spanID := tracing.IDsFromSpanContext(s.tracer, topSpan.Context())
+	c.Set("TraceID", traceID)
+	c.Set("SpanID", spanID)
+
 	// run business logic handler
 	c.Next()
 
-	status, _code, msg := 0, 0, ""
-	switch w := c.Writer.(type) {
-	case *ginHResponseWriter:
-		if !w.written {
-			topSpan.LogFields(log.Object("response", w.json))
-			topSpan.Finish()
-			return
-		}
-		status = w.json["status"].(int)
-		_code = w.json["code"].(int)
-		msg = w.json["message"].(string)
-	default:
-		systemlog.Print("default ResponseWriter cannot get response in TracerSpanStarter middleware")
-		c.Writer.WriteHeader(http.StatusInternalServerError)
-		topSpan.Finish()
+	// read status, code & message from envelope written in handler instead of parsing response body (change in v.1.0.6)
+	resp, ok := envelope.FromContext(c)
+	if !ok {
+		topSpan.LogFields(log.Int("status", c.Writer.Status()), log.String("response", "response is not envelope"))
+		topSpan.SetTag("status", c.Writer.Status()).Finish()
 		return
 	}
+	status, _code, msg := resp.Status, resp.Code, resp.Message
 
 	topSpan.LogFields(log.Int("status", status), log.Int("code", _code), log.String("message", msg))
 	topSpan.SetTag("status", status).SetTag("code", _code).Finish()
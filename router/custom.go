@@ -5,6 +5,7 @@
 package router
 
 import (
+	entityregistry "gateway/entity/registry"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
@@ -13,12 +14,16 @@ import (
 // Additional function is run closure after & before server start or end, etc ...
 type customRouter struct {
 	*gin.Engine
-	beforeRun []func() error
+	beforeRun  []func() error
+	onShutdown []func()                                // functions executed when server start shutting down (add in v.1.0.6)
+	routes     []documentedRoute                       // routes registered through custom router group, used in OpenAPI document (add in v.1.0.6)
+	bound      map[uintptr]entityregistry.BoundHandler // handlers bound with request entity, key is code pointer of handler (add in v.1.0.6)
 }
 
 func New(baseRouter *gin.Engine) (router *customRouter) {
 	router = &customRouter{
 		Engine: baseRouter,
+		bound:  map[uintptr]entityregistry.BoundHandler{},
 	}
 	router.beforeRun = []func() error{}
 
@@ -30,4 +35,5 @@ func New(baseRouter *gin.Engine) (router *customRouter) {
 type customRouterGroup struct {
 	*gin.RouterGroup
 	Validator *validator.Validate
+	router    *customRouter // router recording routes registered in this group (add in v.1.0.6)
 }
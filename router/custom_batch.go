@@ -0,0 +1,212 @@
+// add file in v.1.0.6
+// custom_batch.go is file that declare handler dispatching sub requests in batch request through this router
+// sub request pass same middleware chain (authenticate, validate, cache, etc ...) with request sent from client
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gateway/entity"
+	entityregistry "gateway/entity/registry"
+	"gateway/middleware"
+	"gateway/tool/codec"
+	"gateway/tool/envelope"
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+)
+
+// header of batch request not inherited to sub request, which is set for each sub request
+var nonInheritedHeaders = map[string]bool{
+	"Content-Length":  true,
+	"Content-Type":    true,
+	"X-Request-Id":    true,
+	"Traceparent":     true,
+	"Tracestate":      true,
+	"Accept-Encoding": true, // body of sub response is embedded in batch response without compression (add in v.1.0.6)
+	"If-None-Match":   true, // body of sub response is always embedded in batch response, not 304 (add in v.1.0.6)
+}
+
+// handlers of plain request/response routes which can be sub request of batch request, route not in here is rejected
+// streaming (GetStudentEvents), html page (GetParentActionPage) & batch route itself must not be added (add in v.1.0.6)
+// routes limited with AttemptLimiter (login, auth code & outing code lookup) must not be added either, because concurrent
+// sub requests pass check of limiter together before any failure is counted, which bypasses lockout
+var batchableHandlers = map[string]bool{
+	// auth service
+	"CreateNewStudent": true, "CreateNewTeacher": true, "CreateNewParent": true,
+	"ChangeStudentPW": true, "GetStudentInformWithUUID": true, "GetStudentUUIDsWithInform": true, "GetStudentInformsWithUUIDs": true,
+	"GetParentWithStudentUUID": true, "ChangeTeacherPW": true,
+	"GetTeacherInformWithUUID": true, "GetTeacherUUIDsWithInform": true, "ChangeTeacherInform": true,
+	"ChangeParentPW": true, "GetParentInformWithUUID": true, "GetParentUUIDsWithInform": true, "GetChildrenInformsWithUUID": true,
+	"CreateNewStudentWithAuthCode": true, "SendJoinSMSToUnsignedStudents": true,
+	"AddUnsignedStudentsFromExcel": true,
+	// club service
+	"CreateNewClub": true, "GetClubsSortByUpdateTime": true, "GetRecruitmentsSortByCreateTime": true, "GetClubInformWithUUID": true,
+	"GetClubInformsWithUUIDs": true, "GetRecruitmentInformWithUUID": true, "GetRecruitmentUUIDWithClubUUID": true,
+	"GetRecruitmentUUIDsWithClubUUIDs": true, "GetAllClubFields": true, "GetTotalCountOfClubs": true,
+	"GetTotalCountOfCurrentRecruitments": true, "GetClubUUIDWithLeaderUUID": true, "DeleteClubWithUUID": true,
+	"AddClubMember": true, "DeleteClubMember": true, "ChangeClubLeader": true, "ModifyClubInform": true,
+	"RegisterRecruitment": true, "ModifyRecruitment": true, "DeleteRecruitment": true,
+	// outing service
+	"CreateOuting": true, "GetStudentOutings": true, "GetOutingInform": true, "GetCardAboutOuting": true,
+	"TakeActionInOuting": true, "GetOutingWithFilter": true, "ModifyOuting": true,
+	"IssueParentActionLinks": true, "GetOutingDashboardWithFilter": true,
+	// schedule service
+	"CreateSchedule": true, "GetSchedule": true, "GetTimeTable": true, "UpdateSchedule": true, "DeleteSchedule": true,
+	// announcement service
+	"CreateAnnouncement": true, "GetAnnouncements": true, "GetAnnouncementDetail": true, "UpdateAnnouncement": true,
+	"DeleteAnnouncement": true, "SearchAnnouncements": true, "GetMyAnnouncements": true, "CheckAnnouncement": true,
+	// aggregation & open api
+	"GetStudentDashboard": true, "GetPlaceWithNaverOpenAPI": true, "GraphQL": true,
+	// gateway administration
+	"GetLogLevels": true, "ChangeLogLevel": true, "GetLockouts": true, "ClearLockout": true, "GetAuditRecords": true,
+}
+
+// return handler running sub requests concurrently (at most concurrency at once) & responding status, body of them in order
+// each sub request has own X-Request-Id ({batch X-Request-Id}-{index}) & top span which is child of batch top span
+// batch X-Request-Id is truncated in sub X-Request-Id so that it doesn't exceed max length of accepted X-Request-Id
+func (r *customRouter) Batch(tracer opentracing.Tracer, concurrency int) gin.HandlerFunc {
+	if concurrency <= 0 {
+		log.Fatalln("concurrency of batch sub requests must be positive")
+	}
+
+	handler := func(c *gin.Context) {
+		reqID := c.GetHeader("X-Request-Id")
+
+		// get top span from middleware
+		inAdvanceTopSpan, _ := c.Get("TopSpan")
+		topSpan, _ := inAdvanceTopSpan.(opentracing.Span)
+
+		// get log entry from middleware
+		inAdvanceEntry, _ := c.Get("RequestLogEntry")
+		entry, _ := inAdvanceEntry.(*logrus.Entry)
+
+		// batch request sent as sub request passes SecurityFilter without Request-Security header, so it is rejected (add in v.1.0.6)
+		if middleware.IsInternalRequest(c.Request) {
+			status, _code := http.StatusBadRequest, 0
+			msg := "batch request cannot be included in sub requests of batch request"
+			envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg})
+			entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Info()
+			return
+		}
+
+		// get bound request entry from middleware
+		inAdvanceReq, _ := c.Get("Request")
+		receivedReq, _ := inAdvanceReq.(*entity.BatchRequest)
+
+		responses := make([]gin.H, len(receivedReq.Requests))
+		semaphore := make(chan struct{}, concurrency)
+		wg := sync.WaitGroup{}
+		for i, subReq := range receivedReq.Requests {
+			subReqID := subRequestID(reqID, i)
+			ctx := middleware.WithInternalRequest(c.Request.Context())
+			httpReq, err := http.NewRequestWithContext(ctx, subReq.Method, subReq.Path, bytes.NewReader(subReq.Body))
+			if err != nil {
+				msg := fmt.Sprintf("unable to create sub request, err: %v", err)
+				responses[i] = gin.H{"status": http.StatusBadRequest, "request_id": subReqID,
+					"body": gin.H{"status": http.StatusBadRequest, "code": 0, "message": msg}}
+				continue
+			}
+			// check route of path decoded & cleaned as routed, so escaped path (ex. /v1/%62atch) can't bypass (change in v.1.0.6)
+			if !r.batchable(subReq.Method, path.Clean("/"+httpReq.URL.Path)) {
+				msg := "route of sub request is not found or cannot be included in batch request"
+				responses[i] = gin.H{"status": http.StatusBadRequest, "request_id": subReqID,
+					"body": gin.H{"status": http.StatusBadRequest, "code": 0, "message": msg}}
+				continue
+			}
+			for key, values := range c.Request.Header {
+				if !nonInheritedHeaders[key] {
+					httpReq.Header[key] = append([]string(nil), values...)
+				}
+			}
+			if len(subReq.Body) != 0 {
+				httpReq.Header.Set("Content-Type", "application/json")
+			}
+			httpReq.Header.Set("Accept", codec.MIMEJSON) // body of sub response is embedded in batch response as json (add in v.1.0.6)
+			httpReq.Header.Set("X-Request-Id", subReqID)
+			_ = tracer.Inject(topSpan.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(httpReq.Header))
+			httpReq.RemoteAddr = c.Request.RemoteAddr
+
+			wg.Add(1)
+			go func(i int, httpReq *http.Request) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+
+				recorder := httptest.NewRecorder()
+				r.Engine.ServeHTTP(recorder, httpReq)
+				var body interface{} = recorder.Body.String()
+				if json.Valid(recorder.Body.Bytes()) {
+					body = json.RawMessage(recorder.Body.Bytes())
+				}
+				responses[i] = gin.H{"status": recorder.Code, "request_id": recorder.Header().Get("X-Request-Id"), "body": body}
+			}(i, httpReq)
+		}
+		wg.Wait()
+
+		status, _code := http.StatusOK, 0
+		msg := fmt.Sprintf("succeed to run sub requests of batch request, count: %d", len(responses))
+		envelope.JSON(c, status, gin.H{"status": status, "code": _code, "message": msg, "responses": responses})
+		entry.WithFields(logrus.Fields{"status": status, "code": _code, "message": msg}).Info()
+	}
+	// returned handler is bound with BatchRequest by itself, because it is closure not generated in handler package
+	r.BindHandlers(entityregistry.BoundHandler{Name: "Batch", Handler: handler, Entity: &entityregistry.BatchRequest})
+	return handler
+}
+
+// max length of X-Request-Id accepted in Correlator, which sub X-Request-Id must not exceed
+const maxRequestIDLength = 128
+
+func subRequestID(reqID string, index int) string {
+	suffix := fmt.Sprintf("-%d", index)
+	if len(reqID)+len(suffix) > maxRequestIDLength {
+		reqID = reqID[:maxRequestIDLength-len(suffix)]
+	}
+	return reqID + suffix
+}
+
+// return true if route matched with method & path is registered in custom router group with handler in batchableHandlers
+// static segment is preferred to path parameter as in gin router, ex) /v1/outings/code/:OCode over /v1/outings/uuid/:outing_uuid
+func (r *customRouter) batchable(method, urlPath string) bool {
+	var matched *documentedRoute
+	matchedStatic := -1
+	for i, route := range r.routes {
+		if route.method != method {
+			continue
+		}
+		if static, ok := matchPath(route.path, urlPath); ok && static > matchedStatic {
+			matched, matchedStatic = &r.routes[i], static
+		}
+	}
+	return matched != nil && batchableHandlers[matched.handler.Name]
+}
+
+// return number of static segments if path matches route path in gin format, ex) /v1/students/uuid/:student_uuid
+func matchPath(routePath, urlPath string) (static int, ok bool) {
+	routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+	if len(routeSegments) != len(segments) {
+		return 0, false
+	}
+	for i, segment := range routeSegments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			if segments[i] == "" {
+				return 0, false
+			}
+		case segment == segments[i]:
+			static++
+		default:
+			return 0, false
+		}
+	}
+	return static, true
+}
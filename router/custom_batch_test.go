@@ -0,0 +1,49 @@
+package router
+
+import (
+	entityregistry "gateway/entity/registry"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+// check if only route of handler in batchableHandlers is batchable & static segment is preferred to path parameter (add in v.1.0.6)
+// streaming route & route limited with AttemptLimiter are not batchable
+func TestBatchable(t *testing.T) {
+	r := New(gin.New())
+	outing, events, login := &testHandler{name: "outing"}, &testHandler{name: "events"}, &testHandler{name: "login"}
+	r.BindHandlers(
+		entityregistry.BoundHandler{Name: "GetStudentInformWithUUID", Handler: outing.Bound},
+		entityregistry.BoundHandler{Name: "GetStudentEvents", Handler: events.Unbound},
+		entityregistry.BoundHandler{Name: "LoginStudentAuth", Handler: login.Unbound},
+	)
+	r.CustomGroup("/v1").GET("/students/uuid/:student_uuid", outing.Bound)
+	r.CustomGroup("/v1").GET("/students/uuid/:student_uuid/events", events.Unbound)
+	r.CustomGroup("/v1").POST("/login/student", login.Unbound)
+
+	for _, test := range []struct {
+		method, path string
+		expected     bool
+	}{
+		{"GET", "/v1/students/uuid/student-111111111111", true},
+		{"GET", "/v1/students/uuid/student-111111111111/events", false},
+		{"POST", "/v1/students/uuid/student-111111111111", false},
+		{"GET", "/v1/batch", false},
+		{"POST", "/v1/login/student", false}, // limited with AttemptLimiter
+	} {
+		if batchable := r.batchable(test.method, test.path); batchable != test.expected {
+			t.Errorf("batchable of %s %s must be %t", test.method, test.path, test.expected)
+		}
+	}
+}
+
+// check if sub X-Request-Id doesn't exceed max length even if batch X-Request-Id has max length (add in v.1.0.6)
+func TestSubRequestID(t *testing.T) {
+	if id := subRequestID("batch-request-id", 3); id != "batch-request-id-3" {
+		t.Errorf("sub X-Request-Id must be batch X-Request-Id with index, id: %s", id)
+	}
+	id := subRequestID(strings.Repeat("a", maxRequestIDLength), 12)
+	if len(id) != maxRequestIDLength || !strings.HasSuffix(id, "a-12") {
+		t.Errorf("batch X-Request-Id must be truncated in sub X-Request-Id, id: %s", id)
+	}
+}
@@ -6,6 +6,8 @@ package router
 import (
 	"gateway/middleware"
 	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
 )
 
 // method that return custom router group having method declared in this file
@@ -13,61 +15,82 @@ func (g *customRouterGroup) CustomGroup(relativePath string, handlers ...gin.Han
 	return &customRouterGroup{
 		RouterGroup: g.RouterGroup.Group(relativePath, handlers...),
 		Validator:   g.Validator,
+		router:      g.router,
 	}
 }
 
-// add request validator middleware in front of handlers before routing
+// add request validator middleware in front of handlers before routing (if handler is bound with request entity)
 func (g *customRouterGroup) POST(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPost, relativePath, handler, false)
 	return g.post(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) GET(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodGet, relativePath, handler, false)
 	return g.get(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) DELETE(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodDelete, relativePath, handler, false)
 	return g.delete(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) PATCH(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPatch, relativePath, handler, false)
 	return g.patch(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) PUT(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPut, relativePath, handler, false)
 	return g.put(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 // add authenticator & request validator middleware in front of handlers before routing
 func (g *customRouterGroup) POSTWithAuth(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.Authenticator(), middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPost, relativePath, handler, true)
 	return g.post(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) GETWithAuth(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.Authenticator(), middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodGet, relativePath, handler, true)
 	return g.get(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) DELETEWithAuth(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.Authenticator(), middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodDelete, relativePath, handler, true)
 	return g.delete(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) PATCHWithAuth(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.Authenticator(), middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPatch, relativePath, handler, true)
 	return g.patch(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
 func (g *customRouterGroup) PUTWithAuth(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
-	prefixHandlers := []gin.HandlerFunc{middleware.Authenticator(), middleware.RequestValidator(g.Validator, handler)}
+	prefixHandlers := g.prefixHandlers(http.MethodPut, relativePath, handler, true)
 	return g.put(relativePath, handler, append(prefixHandlers, handlers...)...)
 }
 
+// return middlewares to run in front of handler (authenticator, request validator) & record route in OpenAPI document
+// request validator is added only if handler is bound with request entity, and route of unbound handler is not registered
+// because request entity of that handler is unknown, so handler must be bound with customRouter.BindHandlers (add in v.1.0.6)
+func (g *customRouterGroup) prefixHandlers(method, relativePath string, handler gin.HandlerFunc, auth bool) (prefixHandlers []gin.HandlerFunc) {
+	bound, ok := g.router.boundHandlerOf(handler)
+	if !ok {
+		log.Fatalf("handler of route is not bound with request entity, bind handlers generated with go generate in router, method: %s, path: %s\n",
+			method, g.absolutePath(relativePath))
+	}
+	g.document(method, relativePath, bound, auth)
+
+	if auth {
+		prefixHandlers = append(prefixHandlers, middleware.Authenticator())
+	}
+	if bound.Entity != nil {
+		prefixHandlers = append(prefixHandlers, middleware.RequestValidator(g.Validator, *bound.Entity))
+	}
+	return
+}
+
 // finally call origin POST, GET, DELETE, PATCH, PUT method of RouterGroup
 func (g *customRouterGroup) post(relativePath string, handler gin.HandlerFunc, handlers ...gin.HandlerFunc) gin.IRoutes {
 	return g.RouterGroup.POST(relativePath, append(handlers, handler)...)
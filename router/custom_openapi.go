@@ -0,0 +1,299 @@
+// add file in v.1.0.6
+// custom_openapi.go is file that declare OpenAPI 3 document generated from routes registered in custom router group
+// parameters & request body of each route are read from request entity bound with handler, same as RequestValidator
+
+package router
+
+import (
+	"errors"
+	"fmt"
+	"gateway/entity"
+	entityregistry "gateway/entity/registry"
+	"gateway/tool/codec"
+	"gateway/tool/openapi"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// documentedRoute is route registered with method of customRouterGroup
+type documentedRoute struct {
+	method  string
+	path    string                      // absolute path in gin format, ex) /v1/students/uuid/:student_uuid
+	handler entityregistry.BoundHandler // handler bound with request entity, ex) GetStudentInformWithUUID
+	auth    bool                        // true if registered with authenticator (XXXWithAuth method)
+}
+
+// handlers not binding request entity, which read only path parameters & parameters (query, header) declared in value
+// route whose handler has neither request entity nor item in this map is regarded as undocumented in CheckRoutesDocumented
+var handlersWithoutRequestEntity = map[string][]*openapi.Parameter{
+	// auth service
+	"GetStudentInformWithUUID":   nil,
+	"GetParentWithStudentUUID":   nil,
+	"GetTeacherInformWithUUID":   nil,
+	"GetParentInformWithUUID":    nil,
+	"GetChildrenInformsWithUUID": nil,
+
+	// club service
+	"GetClubInformWithUUID":              nil,
+	"GetRecruitmentInformWithUUID":       nil,
+	"GetRecruitmentUUIDWithClubUUID":     nil,
+	"GetAllClubFields":                   nil,
+	"GetTotalCountOfClubs":               nil,
+	"GetTotalCountOfCurrentRecruitments": nil,
+	"GetClubUUIDWithLeaderUUID":          nil,
+	"DeleteClubWithUUID":                 nil,
+	"DeleteClubMember":                   nil,
+	"DeleteRecruitment":                  nil,
+
+	// outing service
+	"GetOutingInform":    nil,
+	"GetCardAboutOuting": nil,
+	"GetOutingByOCode":   nil,
+	"TakeActionInOuting": {{Name: "token", In: "query", Schema: &openapi.Schema{Type: "string"},
+		Description: "parent action token issued in parent action link, used only in parent-approve & parent-reject action"}},
+	"GetParentActionPage": {{Name: "token", In: "query", Schema: &openapi.Schema{Type: "string"},
+		Description: "parent action token issued in parent action link, verified but not consumed in confirmation page"}},
+
+	// schedule, announcement service
+	"DeleteSchedule":        nil,
+	"GetAnnouncementDetail": nil,
+	"DeleteAnnouncement":    nil,
+	"CheckAnnouncement":     nil,
+
+	// gateway API
+	"GetStudentDashboard": nil,
+	"GetStudentEvents": {
+		{Name: "Last-Event-ID", In: "header", Schema: &openapi.Schema{Type: "string"},
+			Description: "id of last received event, events after this id are sent again"},
+		{Name: "last_event_id", In: "query", Schema: &openapi.Schema{Type: "string"},
+			Description: "used instead of Last-Event-ID header if client can't set header"},
+	},
+	"GetLogLevels": nil,
+	"GetLockouts":  nil,
+}
+
+// description of formats in document, protobuf body is not typed message of each API but google.protobuf.Struct
+const formatsDescription = "Response is encoded in format of Accept header (application/json, application/msgpack, application/x-protobuf) " +
+	"& request body of json fields is also accepted in those formats. application/x-protobuf body is google.protobuf.Struct " +
+	"message converted from json body, not typed message of each API."
+
+const protobufDescription = "google.protobuf.Struct message having fields of json body in same structure. " +
+	"There is no typed message per API to generate class from, and every number is encoded as double (number_value)."
+
+// known response entities responded in success, other routes are documented with common response
+var responseEntities = map[string]interface{}{
+	"GetPlaceWithNaverOpenAPI": entity.GetPlaceWithNaverOpenAPIResponse{},
+}
+
+// record route registered in router group to document it in OpenAPI document
+func (g *customRouterGroup) document(method, relativePath string, handler entityregistry.BoundHandler, auth bool) {
+	g.router.routes = append(g.router.routes, documentedRoute{
+		method:  method,
+		path:    g.absolutePath(relativePath),
+		handler: handler,
+		auth:    auth,
+	})
+}
+
+// return absolute path of relative path in router group, ex) /v1/students/uuid/:student_uuid
+func (g *customRouterGroup) absolutePath(relativePath string) string {
+	return strings.TrimSuffix(g.BasePath(), "/") + "/" + strings.TrimPrefix(relativePath, "/")
+}
+
+// return error if some routes have neither request entity nor item in handlersWithoutRequestEntity
+// this function is registered as before run function, so server doesn't start with undocumented route
+func (r *customRouter) CheckRoutesDocumented() error {
+	var undocumented []string
+	for _, route := range r.routes {
+		if _, ok := handlersWithoutRequestEntity[route.handler.Name]; route.handler.Entity == nil && !ok {
+			undocumented = append(undocumented, fmt.Sprintf("%s %s (%s)", route.method, route.path, route.handler.Name))
+		}
+	}
+
+	if len(undocumented) != 0 {
+		return errors.New(fmt.Sprintf("request entity of some routes is not documented, routes: %s", strings.Join(undocumented, ", ")))
+	}
+	return nil
+}
+
+// build OpenAPI document about routes registered in custom router group until now
+func (r *customRouter) OpenAPIDocument(title, version string) *openapi.Document {
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info:    openapi.Info{Title: title, Version: version, Description: formatsDescription},
+		Paths:   map[string]map[string]*openapi.Operation{},
+		Components: openapi.Components{
+			Schemas: map[string]*openapi.Schema{
+				"Response": {
+					Type:        "object",
+					Description: "common response of gateway, data of API is set in additional properties",
+					Properties: map[string]*openapi.Schema{
+						"status":  {Type: "integer", Description: "http status code"},
+						"code":    {Type: "integer", Description: "detailed code of status, 0 if not needed"},
+						"message": {Type: "string"},
+					},
+					Required:             []string{"status", "code", "message"},
+					AdditionalProperties: true,
+				},
+				"ProtobufStruct": {
+					Type:        "string",
+					Format:      "binary",
+					Description: protobufDescription,
+				},
+			},
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				"request_security": {Type: "apiKey", In: "header", Name: "Request-Security",
+					Description: "encrypted value verifying that request is sent through the proxy"},
+				"jwt": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []openapi.SecurityRequirement{{"request_security": {}}},
+	}
+
+	for _, route := range r.routes {
+		path := openAPIPath(route.path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openapi.Operation{}
+		}
+		doc.Paths[path][strings.ToLower(route.method)] = route.operation()
+	}
+	return doc
+}
+
+// return operation of route with parameters & request body read from request entity
+func (route documentedRoute) operation() *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: route.handler.Name,
+		Tags:        []string{openAPITag(route.path)},
+		Responses: map[string]*openapi.Response{
+			"default": {Description: "response of API", Content: responseContent(openapi.Ref("Response"))},
+			"407":     {Description: "request is not sent through the proxy", Content: responseContent(openapi.Ref("Response"))},
+		},
+	}
+	if route.auth {
+		op.Security = []openapi.SecurityRequirement{{"request_security": {}, "jwt": {}}}
+		op.Responses["401"] = &openapi.Response{Description: "access token is not set or invalid", Content: responseContent(openapi.Ref("Response"))}
+		op.Responses["403"] = &openapi.Response{Description: "user in token is not allowed to call API", Content: responseContent(openapi.Ref("Response"))}
+	}
+	if resp, ok := responseEntities[route.handler.Name]; ok {
+		op.Responses["200"] = &openapi.Response{Description: "success", Content: responseContent(openapi.TypeSchema(reflect.TypeOf(resp), openapi.TagJSON))}
+	}
+
+	documentedPathParams := map[string]bool{}
+	if reqEntity := route.handler.Entity; reqEntity != nil {
+		op.Responses["400"] = &openapi.Response{Description: "request is unable to be bound or not valid", Content: responseContent(openapi.Ref("Response"))}
+
+		if reqEntity.BindsFrom(entityregistry.BindURI) {
+			for _, field := range openapi.StructFields(reqEntity.Type, openapi.TagURI) {
+				op.Parameters = append(op.Parameters, &openapi.Parameter{Name: field.Name, In: "path", Required: true, Schema: field.Schema})
+				documentedPathParams[field.Name] = true
+			}
+		}
+		if reqEntity.BindsFrom(entityregistry.BindQuery) {
+			for _, field := range openapi.StructFields(reqEntity.Type, openapi.TagForm) {
+				op.Parameters = append(op.Parameters, &openapi.Parameter{Name: field.Name, In: "query", Required: field.Required, Schema: field.Schema})
+			}
+		}
+		if reqEntity.BindsFrom(entityregistry.BindBody) {
+			op.RequestBody = &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{}}
+			if fields := openapi.StructFields(reqEntity.Type, openapi.TagForm); len(fields) != 0 {
+				op.RequestBody.Content["multipart/form-data"] = &openapi.MediaType{Schema: fieldsSchema(fields)}
+			}
+			if fields := openapi.StructFields(reqEntity.Type, openapi.TagJSON); len(fields) != 0 {
+				op.RequestBody.Content[codec.MIMEJSON] = &openapi.MediaType{Schema: fieldsSchema(fields)}
+				op.RequestBody.Content[codec.MIMEMsgPack] = &openapi.MediaType{Schema: fieldsSchema(fields)}
+				op.RequestBody.Content[codec.MIMEProtobuf] = &openapi.MediaType{Schema: openapi.Ref("ProtobufStruct")}
+			}
+			if len(op.RequestBody.Content) == 0 {
+				op.RequestBody = nil
+			}
+		}
+	}
+
+	// path parameters not bound in request entity are read in handler with c.Param
+	for _, segment := range strings.Split(route.path, "/") {
+		if name := strings.TrimLeft(segment, ":*"); name != segment && !documentedPathParams[name] {
+			op.Parameters = append(op.Parameters, &openapi.Parameter{Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}})
+		}
+	}
+	op.Parameters = append(op.Parameters, handlersWithoutRequestEntity[route.handler.Name]...)
+	return op
+}
+
+// return object schema having fields as properties
+func fieldsSchema(fields []openapi.Field) *openapi.Schema {
+	schema := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{}}
+	for _, field := range fields {
+		schema.Properties[field.Name] = field.Schema
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// return content of response in formats selected with Accept header, msgpack has same schema with json (change in v.1.0.6)
+func responseContent(schema *openapi.Schema) map[string]*openapi.MediaType {
+	return map[string]*openapi.MediaType{
+		codec.MIMEJSON:     {Schema: schema},
+		codec.MIMEMsgPack:  {Schema: schema},
+		codec.MIMEProtobuf: {Schema: openapi.Ref("ProtobufStruct")},
+	}
+}
+
+// convert gin path to OpenAPI path, ex) /v1/students/uuid/:student_uuid -> /v1/students/uuid/{student_uuid}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// return first resource name in path as tag, ex) /v1/students/uuid/:student_uuid -> students
+func openAPITag(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) > 1 && segments[0] == "v1" {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+// return handler responding OpenAPI document, which is built once in first request after all routes are registered
+func (r *customRouter) OpenAPIHandler(title, version string) gin.HandlerFunc {
+	var doc *openapi.Document
+	once := sync.Once{}
+	return func(c *gin.Context) {
+		once.Do(func() { doc = r.OpenAPIDocument(title, version) })
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// return handler responding Swagger UI page showing document in specURL, assets are loaded from assetsURL
+// assetsURL must be routed with SwaggerUIAssetHandler, ex) /swagger/assets (change in v.1.0.6)
+func (r *customRouter) SwaggerUIHandler(title, specURL, assetsURL string) gin.HandlerFunc {
+	page := []byte(openapi.SwaggerUIHTML(title, specURL, assetsURL))
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	}
+}
+
+// return handler responding asset of Swagger UI compiled into binary, name of asset is read from path parameter named asset
+func (r *customRouter) SwaggerUIAssetHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		asset, ok := openapi.SwaggerUIAssetOf(c.Param("asset"))
+		if !ok {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Header("Cache-Control", "public, max-age=86400")
+		c.Data(http.StatusOK, asset.ContentType, asset.Body)
+	}
+}
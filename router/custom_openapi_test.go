@@ -0,0 +1,36 @@
+package router
+
+import (
+	"gateway/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"os"
+	"testing"
+)
+
+// check if all routes registered in real router with RegisterRoutes are documented, same with before run function of server (add in v.1.0.6)
+func TestRegisteredRoutesDocumented(t *testing.T) {
+	r := New(gin.New())
+	defaultHandler := handler.Default()
+	r.BindHandlers(defaultHandler.BoundHandlers()...)
+	r.RegisterRoutes(defaultHandler, RouteConfig{
+		Tracer:           opentracing.NoopTracer{},
+		RedisClient:      redis.NewClient(&redis.Options{}),
+		BatchConcurrency: 1,
+		GraphQLEnabled:   true, // register all routes including optional one
+		NewLogger: func(group string) *logrus.Logger {
+			logger := logrus.New()
+			logger.SetOutput(os.Stdout)
+			return logger
+		},
+	})
+
+	if len(r.routes) == 0 {
+		t.Fatal("no route is registered in custom router group with RegisterRoutes")
+	}
+	if err := r.CheckRoutesDocumented(); err != nil {
+		t.Error(err)
+	}
+}
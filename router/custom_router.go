@@ -4,10 +4,17 @@
 package router
 
 import (
+	"context"
+	entityregistry "gateway/entity/registry"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
 	"reflect"
+	"syscall"
+	"time"
 )
 
 // register closure function to execute before run
@@ -15,8 +22,18 @@ func (r *customRouter) RegisterBeforeRun(fn ...func() error) {
 	r.beforeRun = append(r.beforeRun, fn...)
 }
 
+// add function executed when server start shutting down, in-flight requests are not canceled (add in v.1.0.6)
+func (r *customRouter) RegisterOnShutdown(fn ...func()) {
+	r.onShutdown = append(r.onShutdown, fn...)
+}
+
+// time to wait for in-flight requests to be finished in shutdown (add in v.1.0.6)
+const shutdownTimeout = time.Second * 10
+
 // overriding run method
 // add executing function before server run
+// server is shut down gracefully if SIGINT or SIGTERM is received, then nil is returned (change in v.1.0.6)
+// so caller can close resources flushing buffer (log sink, audit store, etc ...) after requests are finished
 func (r *customRouter) Run(addr ...string) error {
 	for _, fn := range r.beforeRun {
 		if err := fn(); err != nil {
@@ -25,7 +42,56 @@ func (r *customRouter) Run(addr ...string) error {
 		}
 	}
 
-	return r.Engine.Run(addr...)
+	address := ":8080"
+	if len(addr) != 0 {
+		address = addr[0]
+	}
+	// functions registered on shutdown finish long-lived request (ex. event stream) which Shutdown doesn't wait for
+	server := &http.Server{
+		Addr:    address,
+		Handler: r.Engine,
+	}
+	for _, fn := range r.onShutdown {
+		server.RegisterOnShutdown(fn)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-quit:
+		log.Printf("received %s signal, shutting down server gracefully\n", sig)
+	}
+
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	return server.Shutdown(ctx)
+}
+
+// bind handlers with request entity, which are generated in handler package (ex. handler.(*_default).BoundHandlers)
+// handler routed in custom router group must be bound in advance, request entity of handler is validated in routing (add in v.1.0.6)
+func (r *customRouter) BindHandlers(handlers ...entityregistry.BoundHandler) {
+	for _, h := range handlers {
+		r.bound[handlerPointer(h.Handler)] = h
+	}
+}
+
+// return handler bound with request entity, false is returned if handler is not bound
+func (r *customRouter) boundHandlerOf(handler gin.HandlerFunc) (entityregistry.BoundHandler, bool) {
+	h, ok := r.bound[handlerPointer(handler)]
+	return h, ok
+}
+
+// return code pointer of handler, which is same in method values of same method regardless of receiver (ex. h.CreateOuting)
+func handlerPointer(handler gin.HandlerFunc) uintptr {
+	return reflect.ValueOf(handler).Pointer()
 }
 
 // method that return custom router group having method declared in custom_group.go
@@ -33,5 +99,6 @@ func (r *customRouter) CustomGroup(relativePath string, handlers ...gin.HandlerF
 	return &customRouterGroup{
 		RouterGroup: r.RouterGroup.Group(relativePath, handlers...),
 		Validator:   validator.New(),
+		router:      r,
 	}
 }
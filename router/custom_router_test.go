@@ -0,0 +1,51 @@
+package router
+
+import (
+	entityregistry "gateway/entity/registry"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"testing"
+)
+
+// handler type declaring methods bound in test, same as handler.(*_default)
+type testHandler struct{ name string }
+
+func (h *testHandler) Bound(c *gin.Context)   {}
+func (h *testHandler) Unbound(c *gin.Context) {}
+
+// check if method value of bound handler is found regardless of receiver & other method is not found (add in v.1.0.6)
+func TestBoundHandlerOf(t *testing.T) {
+	r := New(gin.New())
+	reqEntity := &entityregistry.Entity{Name: "BoundRequest"}
+	r.BindHandlers(entityregistry.BoundHandler{Name: "Bound", Handler: (&testHandler{name: "bound"}).Bound, Entity: reqEntity})
+
+	bound, ok := r.boundHandlerOf((&testHandler{name: "routed"}).Bound)
+	if !ok || bound.Name != "Bound" || bound.Entity != reqEntity {
+		t.Errorf("method value of bound handler must be found with request entity, bound: %+v, ok: %t", bound, ok)
+	}
+	if bound, ok := r.boundHandlerOf((&testHandler{}).Unbound); ok {
+		t.Errorf("method not bound must not be found, bound: %+v", bound)
+	}
+}
+
+// check if route of bound handler is registered with request entity & documented
+func TestCustomGroupDocumentsBoundHandler(t *testing.T) {
+	r := New(gin.New())
+	h := &testHandler{}
+	r.BindHandlers(
+		entityregistry.BoundHandler{Name: "Bound", Handler: h.Bound, Entity: &entityregistry.BatchRequest},
+		entityregistry.BoundHandler{Name: "Unbound", Handler: h.Unbound},
+	)
+	r.CustomGroup("/v1").POST("/bound", h.Bound)
+	r.CustomGroup("/v1").GET("/unbound", h.Unbound)
+
+	if len(r.routes) != 2 {
+		t.Fatalf("routes registered in custom router group must be documented, routes: %+v", r.routes)
+	}
+	if route := r.routes[0]; route.path != "/v1/bound" || route.handler.Entity != &entityregistry.BatchRequest {
+		t.Errorf("route of bound handler must be documented with request entity, route: %+v", route)
+	}
+	if err := r.CheckRoutesDocumented(); err == nil || !strings.Contains(err.Error(), "/v1/unbound") {
+		t.Errorf("route of handler without request entity & documentation must be reported, err: %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+// add file in v.1.0.6
+// custom_routes.go is file that declare method registering API routes of handler in custom router groups
+// routes are registered in this method instead of main function, so that test checks routes registered in real router
+
+package router
+
+import (
+	"gateway/entity/validator"
+	"gateway/handler"
+	"gateway/middleware"
+	"gateway/tool/audit"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// RouteConfig is dependencies & settings of middlewares registered with API routes
+type RouteConfig struct {
+	Tracer                  opentracing.Tracer
+	RedisClient             *redis.Client
+	RedisSetTopic           string
+	RedisDelTopic           string
+	CoalescingLockTTL       time.Duration // TTL of redis lock coalescing requests across replicas, 0 disables lock
+	CompressionMinSize      int           // response smaller than this size (bytes) is not compressed
+	CompressionContentTypes []string
+	AuditStore              audit.Store
+	BatchConcurrency        int  // max number of sub requests in batch request running at once
+	GraphQLEnabled          bool // expose GraphQL facade API only if enabled
+
+	// create logger of route group (auth, club, ...), log sinks are injected so that test can log to stdout
+	NewLogger func(group string) *logrus.Logger
+}
+
+// register API routes of default handler in custom router groups with middlewares run after successful routing matching
+// handlers of default handler must be bound with BindHandlers before calling this method
+func (r *customRouter) RegisterRoutes(defaultHandler *handler.DefaultHandler, cfg RouteConfig) {
+	// create logger per group with function set in config, main creates logger writing to sinks set in LOG_SINK environment variable
+	authLogger := cfg.NewLogger("auth")
+	clubLogger := cfg.NewLogger("club")
+	outingLogger := cfg.NewLogger("outing")
+	scheduleLogger := cfg.NewLogger("schedule")
+	announcementLogger := cfg.NewLogger("announcement")
+	openApiLogger := cfg.NewLogger("open-api")
+	excelApiLogger := cfg.NewLogger("excel-api")
+	adminLogger := cfg.NewLogger("admin")
+	dashboardLogger := cfg.NewLogger("dashboard")
+	batchLogger := cfg.NewLogger("batch")
+	graphQLLogger := cfg.NewLogger("graphql")
+	eventLogger := cfg.NewLogger("event")
+
+	router := r.CustomGroup("/",
+		middleware.ResponseCompressor(cfg.CompressionMinSize, cfg.CompressionContentTypes), // compress response under custom writer (add in v.1.0.6)
+		middleware.GinHResponseWriter(),          // change ResponseWriter in *gin.Context to custom writer overriding that (add in v.1.0.3)
+		middleware.TracerSpanStarter(cfg.Tracer), // start, end top span of tracer & set log, tag about response (add in v.1.0.3)
+		middleware.FieldSelector(),               // project response into fields in fields query parameter (add in v.1.0.6)
+	)
+	router.Validator = validator.New()
+	redisHandler := middleware.RedisHandler(cfg.RedisClient, cfg.Tracer, cfg.RedisSetTopic, cfg.RedisDelTopic, cfg.CoalescingLockTTL, cfg.CompressionMinSize)
+	attemptLimiter := middleware.AttemptLimiter(cfg.RedisClient) // add in v.1.0.6
+	auditRecorder := middleware.AuditRecorder(cfg.AuditStore)    // add in v.1.0.6
+
+	// routing auth service API
+	authRouter := router.CustomGroup("/", middleware.LogEntrySetter(authLogger))
+	// auth service api for admin
+	authRouter.POSTWithAuth("/v1/students", defaultHandler.CreateNewStudent, auditRecorder.Recorder())
+	authRouter.POSTWithAuth("/v1/parents", defaultHandler.CreateNewParent, auditRecorder.Recorder())
+	authRouter.POST("/v1/login/admin", defaultHandler.LoginAdminAuth, attemptLimiter.LoginAdminAuth())
+	authRouter.POSTWithAuth("/v1/join-sms/unsigned-students", defaultHandler.SendJoinSMSToUnsignedStudents, auditRecorder.Recorder())
+	// auth service api for student
+	authRouter.POST("/v1/login/student", defaultHandler.LoginStudentAuth, attemptLimiter.LoginStudentAuth())
+	authRouter.PUTWithAuth("/v1/students/uuid/:student_uuid/password", defaultHandler.ChangeStudentPW)
+	authRouter.GETWithAuth("/v1/students/uuid/:student_uuid", defaultHandler.GetStudentInformWithUUID)
+	authRouter.GETWithAuth("/v1/student-uuids", defaultHandler.GetStudentUUIDsWithInform)
+	authRouter.POSTWithAuth("/v1/students/with-uuids", defaultHandler.GetStudentInformsWithUUIDs)
+	authRouter.GETWithAuth("/v1/students/uuid/:student_uuid/parent", defaultHandler.GetParentWithStudentUUID)
+	authRouter.GET("/v1/students/auth-code/:auth_code", defaultHandler.GetUnsignedStudentWithAuthCode, attemptLimiter.GetUnsignedStudentWithAuthCode())
+	authRouter.POST("/v1/students/with-code", defaultHandler.CreateNewStudentWithAuthCode)
+	// auth service api for teacher
+	authRouter.POST("/v1/teachers", defaultHandler.CreateNewTeacher)
+	authRouter.POST("/v1/login/teacher", defaultHandler.LoginTeacherAuth, attemptLimiter.LoginTeacherAuth())
+	authRouter.POST("/v1/login/teacher/with-pick", defaultHandler.LoginTeacherAuthWithPICK, attemptLimiter.LoginTeacherAuthWithPICK())
+	authRouter.PUTWithAuth("/v1/teachers/uuid/:teacher_uuid/password", defaultHandler.ChangeTeacherPW)
+	authRouter.GETWithAuth("/v1/teachers/uuid/:teacher_uuid", defaultHandler.GetTeacherInformWithUUID)
+	authRouter.GETWithAuth("/v1/teacher-uuids", defaultHandler.GetTeacherUUIDsWithInform)
+	authRouter.PATCHWithAuth("/v1/teachers/uuid/:teacher_uuid", defaultHandler.ChangeTeacherInform)
+	// auth service api for parent
+	authRouter.POST("/v1/login/parent", defaultHandler.LoginParentAuth, attemptLimiter.LoginParentAuth())
+	authRouter.PUTWithAuth("/v1/parents/uuid/:parent_uuid/password", defaultHandler.ChangeParentPW)
+	authRouter.GETWithAuth("/v1/parents/uuid/:parent_uuid", defaultHandler.GetParentInformWithUUID)
+	authRouter.GETWithAuth("/v1/parent-uuids", defaultHandler.GetParentUUIDsWithInform)
+	authRouter.GETWithAuth("/v1/parents/uuid/:parent_uuid/children", defaultHandler.GetChildrenInformsWithUUID)
+
+	// routing club service API
+	clubRouter := router.CustomGroup("/", middleware.LogEntrySetter(clubLogger))
+	// club service api for admin
+	clubRouter.POSTWithAuth("/v1/clubs", defaultHandler.CreateNewClub, auditRecorder.Recorder())
+	// club service api for student
+	clubRouter.GETWithAuth("/v1/clubs/sorted-by/update-time", defaultHandler.GetClubsSortByUpdateTime, middleware.CursorPaginator("clubs", "club_uuid"))
+	clubRouter.GETWithAuth("/v1/recruitments/sorted-by/create-time", defaultHandler.GetRecruitmentsSortByCreateTime)
+	clubRouter.GETWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.GetClubInformWithUUID)
+	clubRouter.GETWithAuth("/v1/clubs", defaultHandler.GetClubInformsWithUUIDs)
+	clubRouter.GETWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.GetRecruitmentInformWithUUID)
+	clubRouter.GETWithAuth("/v1/clubs/uuid/:club_uuid/recruitment-uuid", defaultHandler.GetRecruitmentUUIDWithClubUUID)
+	clubRouter.GETWithAuth("/v1/recruitment-uuids", defaultHandler.GetRecruitmentUUIDsWithClubUUIDs)
+	clubRouter.GETWithAuth("/v1/clubs/property/fields", defaultHandler.GetAllClubFields)
+	clubRouter.GETWithAuth("/v1/clubs/count", defaultHandler.GetTotalCountOfClubs)
+	clubRouter.GETWithAuth("/v1/recruitments/count", defaultHandler.GetTotalCountOfCurrentRecruitments)
+	clubRouter.GETWithAuth("/v1/leaders/uuid/:leader_uuid/club-uuid", defaultHandler.GetClubUUIDWithLeaderUUID)
+	// club service api for club leader
+	clubRouter.DELETEWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.DeleteClubWithUUID)
+	clubRouter.POSTWithAuth("/v1/clubs/uuid/:club_uuid/members", defaultHandler.AddClubMember)
+	clubRouter.DELETEWithAuth("/v1/clubs/uuid/:club_uuid/members/:student_uuid", defaultHandler.DeleteClubMember)
+	clubRouter.PUTWithAuth("/v1/clubs/uuid/:club_uuid/leader", defaultHandler.ChangeClubLeader)
+	clubRouter.PATCHWithAuth("/v1/clubs/uuid/:club_uuid", defaultHandler.ModifyClubInform)
+	clubRouter.POSTWithAuth("/v1/recruitments", defaultHandler.RegisterRecruitment)
+	clubRouter.PATCHWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.ModifyRecruitment)
+	clubRouter.DELETEWithAuth("/v1/recruitments/uuid/:recruitment_uuid", defaultHandler.DeleteRecruitment)
+
+	// routing outing service API
+	outingRouter := router.CustomGroup("/", middleware.LogEntrySetter(outingLogger))
+	outingRouter.POSTWithAuth("/v1/outings", defaultHandler.CreateOuting, redisHandler.CreateOuting()...)
+	outingRouter.GETWithAuth("/v1/students/uuid/:student_uuid/outings", defaultHandler.GetStudentOutings, append([]gin.HandlerFunc{
+		middleware.CursorPaginator("outings", "outing_uuid")}, redisHandler.GetStudentOutings()...)...)
+	outingRouter.GETWithAuth("/v1/outings/uuid/:outing_uuid", defaultHandler.GetOutingInform, redisHandler.GetOutingInform()...)
+	outingRouter.GETWithAuth("/v1/outings/uuid/:outing_uuid/card", defaultHandler.GetCardAboutOuting, redisHandler.GetCardAboutOuting()...)
+	outingRouter.POST("/v1/outings/uuid/:outing_uuid/actions/:action", defaultHandler.TakeActionInOuting, append(redisHandler.TakeActionInOuting(),
+		auditRecorder.Recorder("teacher-approve", "teacher-reject", "certify"))...)
+	outingRouter.GET("/v1/outings/uuid/:outing_uuid/actions/:action", defaultHandler.GetParentActionPage) // add in v.1.0.6
+	outingRouter.GETWithAuth("/v1/outings/with-filter", defaultHandler.GetOutingWithFilter, redisHandler.GetOutingWithFilter()...)
+	outingRouter.GETWithAuth("/v1/outings/with-filter/dashboard", defaultHandler.GetOutingDashboardWithFilter) // add in v.1.0.6
+	outingRouter.GET("/v1/outings/code/:OCode", defaultHandler.GetOutingByOCode, attemptLimiter.GetOutingByOCode())
+	outingRouter.POSTWithAuth("/v1/outings/uuid/:outing_uuid/parent-action-links", defaultHandler.IssueParentActionLinks, auditRecorder.Recorder()) // add in v.1.0.6
+	outingRouter.PATCHWithAuth("/v1/outings/uuid/:outing_uuid", defaultHandler.ModifyOuting, redisHandler.ModifyOuting()...)
+
+	// routing schedule service API
+	scheduleRouter := router.CustomGroup("/", middleware.LogEntrySetter(scheduleLogger))
+	scheduleRouter.POSTWithAuth("/v1/schedules", defaultHandler.CreateSchedule, redisHandler.CreateSchedule()...)
+	scheduleRouter.GETWithAuth("/v1/schedules/years/:year/months/:month", defaultHandler.GetSchedule, redisHandler.GetSchedule()...)
+	scheduleRouter.GETWithAuth("/v1/time-tables/years/:year/months/:month/days/:day", defaultHandler.GetTimeTable, redisHandler.GetTimeTable()...)
+	scheduleRouter.PATCHWithAuth("/v1/schedules/uuid/:schedule_uuid", defaultHandler.UpdateSchedule, redisHandler.UpdateSchedule()...)
+	scheduleRouter.DELETEWithAuth("/v1/schedules/uuid/:schedule_uuid", defaultHandler.DeleteSchedule, redisHandler.DeleteSchedule()...)
+
+	// routing announcement service API
+	announcementRouter := router.CustomGroup("/", middleware.LogEntrySetter(announcementLogger))
+	announcementRouter.POSTWithAuth("/v1/announcements", defaultHandler.CreateAnnouncement, redisHandler.CreateAnnouncement()...)
+	announcementRouter.GETWithAuth("/v1/announcements/types/:type", defaultHandler.GetAnnouncements, append([]gin.HandlerFunc{
+		middleware.CursorPaginator("announcements", "announcement_uuid")}, redisHandler.GetAnnouncements()...)...)
+	announcementRouter.GETWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.GetAnnouncementDetail, redisHandler.GetAnnouncementDetail()...)
+	announcementRouter.PATCHWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.UpdateAnnouncement, redisHandler.UpdateAnnouncement()...)
+	announcementRouter.DELETEWithAuth("/v1/announcements/uuid/:announcement_uuid", defaultHandler.DeleteAnnouncement, redisHandler.DeleteAnnouncement()...)
+	announcementRouter.GETWithAuth("/v1/students/uuid/:student_uuid/announcement-check", defaultHandler.CheckAnnouncement, redisHandler.CheckAnnouncement()...)
+	announcementRouter.GETWithAuth("/v1/announcements/types/:type/query/:search_query", defaultHandler.SearchAnnouncements, redisHandler.SearchAnnouncements()...)
+	announcementRouter.GETWithAuth("/v1/announcements/writer-uuid/:writer_uuid", defaultHandler.GetMyAnnouncements, append([]gin.HandlerFunc{
+		middleware.CursorPaginator("announcements", "announcement_uuid")}, redisHandler.GetMyAnnouncements()...)...)
+
+	// routing open-api agent API
+	openApiRouter := router.CustomGroup("/", middleware.LogEntrySetter(openApiLogger))
+	openApiRouter.GETWithAuth("/naver-open-api/search/local", defaultHandler.GetPlaceWithNaverOpenAPI)
+
+	// routing excel handling API
+	excelApiRouter := router.CustomGroup("/", middleware.LogEntrySetter(excelApiLogger))
+	excelApiRouter.POSTWithAuth("/v1/unsigned-students/parsed-by/excel", defaultHandler.AddUnsignedStudentsFromExcel, auditRecorder.Recorder())
+	excelApiRouter.POSTWithAuth("/v1/unsigned-students/parsed-by/excel/sheets/:sheet", defaultHandler.AddUnsignedStudentsFromExcel, auditRecorder.Recorder())
+
+	// routing dashboard API aggregating several services (add in v.1.0.6)
+	dashboardRouter := router.CustomGroup("/", middleware.LogEntrySetter(dashboardLogger))
+	dashboardRouter.GETWithAuth("/v1/students/uuid/:student_uuid/dashboard", defaultHandler.GetStudentDashboard)
+
+	// routing Server-Sent Events stream pushing changes to student (add in v.1.0.6)
+	eventRouter := router.CustomGroup("/", middleware.LogEntrySetter(eventLogger))
+	eventRouter.GETWithAuth("/v1/students/uuid/:student_uuid/events", defaultHandler.GetStudentEvents)
+
+	// routing batch API running several API in one request (add in v.1.0.6)
+	batchRouter := router.CustomGroup("/", middleware.LogEntrySetter(batchLogger))
+	batchRouter.POST("/v1/batch", r.Batch(cfg.Tracer, cfg.BatchConcurrency))
+
+	// routing GraphQL facade API calling same services with REST API (add in v.1.0.6)
+	if cfg.GraphQLEnabled {
+		graphQLRouter := router.CustomGroup("/", middleware.LogEntrySetter(graphQLLogger))
+		graphQLRouter.POSTWithAuth("/graphql", defaultHandler.GraphQL)
+	}
+
+	// routing gateway administration API (add in v.1.0.6)
+	adminRouter := router.CustomGroup("/", middleware.LogEntrySetter(adminLogger))
+	adminRouter.GETWithAuth("/v1/admin/log-levels", defaultHandler.GetLogLevels)
+	adminRouter.PUTWithAuth("/v1/admin/log-levels", defaultHandler.ChangeLogLevel, auditRecorder.Recorder())
+	adminRouter.GETWithAuth("/v1/admin/lockouts", defaultHandler.GetLockouts)
+	adminRouter.DELETEWithAuth("/v1/admin/lockouts", defaultHandler.ClearLockout, auditRecorder.Recorder())
+	adminRouter.GETWithAuth("/v1/admin/audit-records", defaultHandler.GetAuditRecords)
+}
@@ -0,0 +1,53 @@
+// add file in v.1.0.6
+// policy.go is file that declare policies of endpoints protected from brute-force attack
+
+package attempt
+
+import "time"
+
+// policies applied per account id in login API
+var (
+	StudentLoginPolicy = accountPolicy("login-student")
+	TeacherLoginPolicy = accountPolicy("login-teacher")
+	ParentLoginPolicy  = accountPolicy("login-parent")
+	AdminLoginPolicy   = accountPolicy("login-admin")
+)
+
+// policies applied per client ip in login & code lookup API, more failures are allowed than account policy
+// because clients in the same school network can share ip
+var (
+	LoginIPPolicy = Policy{
+		Scope:           "login-ip",
+		MaxFailures:     30,
+		Window:          time.Minute * 10,
+		LockoutDuration: time.Minute * 15,
+		BaseDelay:       0,
+	}
+	AuthCodePolicy = Policy{
+		Scope:           "auth-code",
+		MaxFailures:     10,
+		Window:          time.Minute * 10,
+		LockoutDuration: time.Minute * 30,
+		BaseDelay:       time.Millisecond * 200,
+		MaxDelay:        time.Second * 3,
+	}
+	OutingCodePolicy = Policy{
+		Scope:           "outing-code",
+		MaxFailures:     10,
+		Window:          time.Minute * 10,
+		LockoutDuration: time.Minute * 30,
+		BaseDelay:       time.Millisecond * 200,
+		MaxDelay:        time.Second * 3,
+	}
+)
+
+func accountPolicy(scope string) Policy {
+	return Policy{
+		Scope:           scope,
+		MaxFailures:     5,
+		Window:          time.Minute * 10,
+		LockoutDuration: time.Minute * 15,
+		BaseDelay:       time.Millisecond * 500,
+		MaxDelay:        time.Second * 5,
+	}
+}
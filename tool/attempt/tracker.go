@@ -0,0 +1,148 @@
+// add package in v.1.0.6
+// this package is used to track failed attempts (login, code lookup, etc ...) in redis to protect from brute-force attack
+// tracker.go is file that declare tracker counting failure, delaying & locking out key (account id, client ip)
+
+package attempt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"math"
+	"strings"
+	"time"
+)
+
+// format of redis key, failure counter is kept for Window & lockout is kept for LockoutDuration
+const (
+	failureKeyFormat = "attempt:failure:%s:%s"
+	lockoutKeyFormat = "attempt:lockout:%s:%s"
+	lockoutKeyPrefix = "attempt:lockout:"
+)
+
+// increase failure counter & set expiration only if counter has no expiration, in one atomic script
+// counter is never left without expiration even if previous expiration was failed
+var incrFailureScript = redis.NewScript(`
+local failures = redis.call("INCR", KEYS[1])
+if redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return failures`)
+
+// Policy decide how many failures key can have before locked out & how long request of key is delayed
+type Policy struct {
+	Scope           string        // name separating counter of each endpoint group (ex. login-student)
+	MaxFailures     int64         // key is locked out when failed this times within Window
+	Window          time.Duration // failure counter is reset after this duration from first failure
+	LockoutDuration time.Duration
+	BaseDelay       time.Duration // delay of request after first failure, doubled every failure
+	MaxDelay        time.Duration
+}
+
+// Lockout is information of locked out key returned from Lockouts method
+type Lockout struct {
+	Scope  string        `json:"scope"`
+	Key    string        `json:"key"`
+	Remain time.Duration `json:"-"`
+}
+
+type Tracker struct {
+	client *redis.Client
+}
+
+func NewTracker(client *redis.Client) *Tracker {
+	return &Tracker{client: client}
+}
+
+// return key of account id & client ip used in tracker, (ex. account:jinhong07191, ip:127.0.0.1)
+func AccountKey(id string) string { return "account:" + id }
+func IPKey(ip string) string      { return "ip:" + ip }
+
+// return remaining time if key is locked out, else delay to apply progressively for the number of failures
+func (t *Tracker) Check(ctx context.Context, p Policy, key string) (locked bool, remain, delay time.Duration, err error) {
+	remain, err = t.client.TTL(ctx, fmt.Sprintf(lockoutKeyFormat, p.Scope, key)).Result()
+	if err != nil {
+		return
+	}
+	if remain > 0 || remain == -1 { // -1 means key exists without expiration
+		locked = true
+		return
+	}
+	remain = 0
+
+	failures, err := t.client.Get(ctx, fmt.Sprintf(failureKeyFormat, p.Scope, key)).Int64()
+	if err == redis.Nil {
+		err = nil
+		return
+	}
+	if err != nil || failures <= 0 || p.BaseDelay <= 0 {
+		return
+	}
+
+	delay = time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(failures-1)))
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return
+}
+
+// count failure of key & lock out key if number of failures reach max failures of policy
+func (t *Tracker) Fail(ctx context.Context, p Policy, key string) (locked bool, err error) {
+	failureKey := fmt.Sprintf(failureKeyFormat, p.Scope, key)
+	failures, err := incrFailureScript.Run(ctx, t.client, []string{failureKey}, p.Window.Milliseconds()).Int64()
+	if err != nil {
+		return
+	}
+	if failures < p.MaxFailures {
+		return
+	}
+
+	if err = t.client.Set(ctx, fmt.Sprintf(lockoutKeyFormat, p.Scope, key), failures, p.LockoutDuration).Err(); err != nil {
+		return
+	}
+	t.client.Del(ctx, failureKey)
+	locked = true
+	return
+}
+
+// reset failure counter of key after successful attempt
+func (t *Tracker) Succeed(ctx context.Context, p Policy, key string) error {
+	return t.client.Del(ctx, fmt.Sprintf(failureKeyFormat, p.Scope, key)).Err()
+}
+
+// return all keys locked out now
+func (t *Tracker) Lockouts(ctx context.Context) (lockouts []Lockout, err error) {
+	var cursor uint64
+	for {
+		var keys []string
+		if keys, cursor, err = t.client.Scan(ctx, cursor, lockoutKeyPrefix+"*", 100).Result(); err != nil {
+			return
+		}
+		for _, key := range keys {
+			// key format is attempt:lockout:{scope}:{kind}:{value}
+			fields := strings.SplitN(strings.TrimPrefix(key, lockoutKeyPrefix), ":", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			remain, _ := t.client.TTL(ctx, key).Result()
+			lockouts = append(lockouts, Lockout{Scope: fields[0], Key: fields[1], Remain: remain})
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+	return
+}
+
+// clear lockout & failure counter of key in scope
+func (t *Tracker) Clear(ctx context.Context, scope, key string) error {
+	deleted, err := t.client.Del(ctx, fmt.Sprintf(lockoutKeyFormat, scope, key), fmt.Sprintf(failureKeyFormat, scope, key)).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.New(fmt.Sprintf("lockout or failure of key %s in scope %s does not exist", key, scope))
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// add file in v.1.0.6
+// jsonl_store.go is file that declare store saving audit record in local file as json lines chained with hash
+// hash of each record is sha256 of previous hash & record, so modifying or deleting middle record breaks the chain
+// query reads file until size snapshotted in lock without holding lock, so appending record isn't blocked in query (change in v.1.0.6)
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// hash of previous record used in the first record
+const genesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+type jsonlStore struct {
+	path     string
+	file     *os.File
+	lastHash string
+	size     int64 // size of file written until now, records in this size are complete lines
+	failed   error // error of file which couldn't be restored after failed append, no more record is appended if set
+	mutex    sync.Mutex
+}
+
+// open (or create) file of path & verify hash chain of records already saved in that file
+func NewJSONLStore(path string) (Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to make audit directory, path: %s, err: %v", path, err))
+	}
+
+	s := &jsonlStore{path: path, lastHash: genesisHash}
+	if err := s.scan(-1, func(r Record) error {
+		if r.PrevHash != s.lastHash || hashOf(r) != r.Hash {
+			return errors.New(fmt.Sprintf("hash chain of audit record is broken, time: %s, hash: %s", r.Time, r.Hash))
+		}
+		s.lastHash = r.Hash
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to open audit file, path: %s, err: %v", path, err))
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, errors.New(fmt.Sprintf("unable to get size of audit file, path: %s, err: %v", path, err))
+	}
+	s.file, s.size = file, info.Size()
+	return s, nil
+}
+
+// size & last hash are advanced together only if record is written & synced, otherwise file is truncated to previous size
+// so that torn line or record not chained by next one is not left in file
+func (s *jsonlStore) Append(_ context.Context, r *Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failed != nil {
+		return errors.New(fmt.Sprintf("audit store is failed by previous append, err: %v", s.failed))
+	}
+
+	r.PrevHash = s.lastHash
+	r.Hash = hashOf(*r)
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.file.Write(append(line, '\n'))
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		if truncErr := s.file.Truncate(s.size); truncErr != nil {
+			s.failed = errors.New(fmt.Sprintf("unable to truncate audit file after failed append, path: %s, err: %v", s.path, truncErr))
+		}
+		return err
+	}
+	s.size += int64(len(line) + 1)
+	s.lastHash = r.Hash
+	return nil
+}
+
+// scan records written before query in file, records appended while scanning are not included
+func (s *jsonlStore) Query(_ context.Context, f Filter) (records []Record, err error) {
+	s.mutex.Lock()
+	size := s.size
+	s.mutex.Unlock()
+
+	err = s.scan(size, func(r Record) error {
+		if f.Match(r) {
+			records = append(records, r)
+		}
+		return nil
+	})
+	if f.Limit > 0 && len(records) > f.Limit {
+		records = records[len(records)-f.Limit:]
+	}
+	return
+}
+
+func (s *jsonlStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
+
+// call handle with records in first size bytes of file in order (all records if size is negative)
+// not existing file is regarded as empty
+func (s *jsonlStore) scan(size int64, handle func(Record) error) error {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.New(fmt.Sprintf("unable to open audit file, path: %s, err: %v", s.path, err))
+	}
+	defer func() { _ = file.Close() }()
+
+	var reader io.Reader = file
+	if size >= 0 {
+		reader = io.LimitReader(file, size)
+	}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return errors.New(fmt.Sprintf("unable to unmarshal audit record, err: %v", err))
+		}
+		if err := handle(r); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// return sha256 of record json without hash field, PrevHash must be set before calling this function
+func hashOf(r Record) string {
+	r.Hash = ""
+	b, _ := json.Marshal(r)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// check if query returns complete records chained with hash while records are appended concurrently (add in v.1.0.6)
+func TestJSONLStoreQueryWhileAppending(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory, err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	store, err := NewJSONLStore(path)
+	if err != nil {
+		t.Fatalf("unable to open store, err: %v", err)
+	}
+	const appended = 100
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < appended; i++ {
+			if err := store.Append(context.Background(), &Record{Time: time.Now(), ActorUUID: "admin-111111111111"}); err != nil {
+				t.Errorf("unable to append record, err: %v", err)
+				return
+			}
+		}
+	}()
+
+	last := 0
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		records, err := store.Query(context.Background(), Filter{ActorUUID: "admin-111111111111"})
+		if err != nil {
+			t.Fatalf("unable to query records while appending, err: %v", err)
+		}
+		if len(records) < last {
+			t.Fatalf("records appended before query must be returned, previous: %d, current: %d", last, len(records))
+		}
+		prevHash := genesisHash
+		for _, r := range records {
+			if r.PrevHash != prevHash || hashOf(r) != r.Hash {
+				t.Fatalf("queried record is not complete or chained, record: %+v", r)
+			}
+			prevHash = r.Hash
+		}
+		last = len(records)
+	}
+	if last != appended {
+		t.Fatalf("all appended records must be returned after appending, expected: %d, actual: %d", appended, last)
+	}
+
+	if err = store.Close(); err != nil {
+		t.Fatalf("unable to close store, err: %v", err)
+	}
+	reopened, err := NewJSONLStore(path)
+	if err != nil {
+		t.Fatalf("hash chain of records must be verified in reopened store, err: %v", err)
+	}
+	_ = reopened.Close()
+}
+
+// check if only last records of limit are returned in query
+func TestJSONLStoreQueryLimit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory, err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewJSONLStore(filepath.Join(dir, "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("unable to open store, err: %v", err)
+	}
+	defer store.Close()
+	for _, route := range []string{"/a", "/b", "/c"} {
+		if err := store.Append(context.Background(), &Record{Route: route}); err != nil {
+			t.Fatalf("unable to append record, err: %v", err)
+		}
+	}
+
+	records, err := store.Query(context.Background(), Filter{Limit: 2})
+	if err != nil || len(records) != 2 || records[0].Route != "/b" || records[1].Route != "/c" {
+		t.Errorf("last records of limit must be returned, records: %+v, err: %v", records, err)
+	}
+}
+
+// check if failed append doesn't leave record in file & store refuses appending if file can't be restored (add in v.1.0.6)
+func TestJSONLStoreFailedAppend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("unable to create temporary directory, err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	store, err := NewJSONLStore(path)
+	if err != nil {
+		t.Fatalf("unable to open store, err: %v", err)
+	}
+	if err := store.Append(context.Background(), &Record{Route: "/a"}); err != nil {
+		t.Fatalf("unable to append record, err: %v", err)
+	}
+
+	// file opened as read only fails both of writing & truncating
+	jsonl := store.(*jsonlStore)
+	writable := jsonl.file
+	if jsonl.file, err = os.Open(path); err != nil {
+		t.Fatalf("unable to open audit file as read only, err: %v", err)
+	}
+	if err := store.Append(context.Background(), &Record{Route: "/b"}); err == nil {
+		t.Fatalf("append to read only file must fail")
+	}
+	_ = jsonl.file.Close()
+	jsonl.file = writable
+	if err := store.Append(context.Background(), &Record{Route: "/c"}); err == nil {
+		t.Errorf("store which couldn't restore file must refuse appending")
+	}
+	_ = store.Close()
+
+	reopened, err := NewJSONLStore(path)
+	if err != nil {
+		t.Fatalf("hash chain must not be broken by failed append, err: %v", err)
+	}
+	defer reopened.Close()
+	records, err := reopened.Query(context.Background(), Filter{})
+	if err != nil || len(records) != 1 || records[0].Route != "/a" {
+		t.Errorf("only record appended successfully must be saved, records: %+v, err: %v", records, err)
+	}
+}
@@ -0,0 +1,69 @@
+// add package in v.1.0.6
+// this package is used to record privileged operations (creating account, approving outing, etc ...) in append-only store
+// record.go is file that declare audit record, filter & interface of store saving records
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Record is who (actor) did what (route, request) to which targets with what outcome
+type Record struct {
+	Time      time.Time         `json:"time"`
+	ActorUUID string            `json:"actor_uuid"`
+	Method    string            `json:"method"`
+	Route     string            `json:"route"`
+	Path      string            `json:"path"`
+	Request   json.RawMessage   `json:"request,omitempty"` // request entity with sensitive fields redacted
+	Targets   map[string]string `json:"targets,omitempty"` // ex. {"student_uuid": "student-111111111111"}
+	Status    int               `json:"status"`
+	Code      int               `json:"code"`
+	Message   string            `json:"message"`
+	RequestID string            `json:"request_id"`
+	TraceID   string            `json:"trace_id"`
+	PrevHash  string            `json:"prev_hash"`
+	Hash      string            `json:"hash"`
+}
+
+// Filter is condition of records to query, zero value field is not used as condition
+type Filter struct {
+	ActorUUID string
+	Target    string // matched with any value of targets
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// return true if record satisfy all conditions of filter
+func (f Filter) Match(r Record) bool {
+	if f.ActorUUID != "" && r.ActorUUID != f.ActorUUID {
+		return false
+	}
+	if !f.From.IsZero() && r.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && r.Time.After(f.To) {
+		return false
+	}
+	if f.Target != "" {
+		for _, target := range r.Targets {
+			if target == f.Target {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Store is append-only storage of audit record, implementation can be local file, database, etc ...
+type Store interface {
+	// set PrevHash, Hash of record to chain it with last record & save it
+	Append(ctx context.Context, r *Record) error
+	// return records matched with filter in order of time, latest records if more than limit
+	Query(ctx context.Context, f Filter) ([]Record, error)
+	Close() error
+}
@@ -0,0 +1,18 @@
+// add package in v.1.0.6
+// this package is used to declare response codes used only in gateway, which are not declared in utils/code
+// code.go is file that declare codes continued from the last code of gateway in utils/code (UnsupportedContentType = 1010)
+
+package code
+
+const (
+	InvalidParentActionToken  = 1011 // signature, expiration or binding (outing, action) of parent action token is invalid
+	ConsumedParentActionToken = 1012 // parent action token was already used or revoked
+	TooManyFailedAttempts     = 1013 // client or account is locked out because of too many failed attempts
+	PartialDashboardSections  = 1014 // some sections of dashboard failed, each failed section has own status, code & message
+	InvalidGraphQLQuery       = 1015 // GraphQL query has syntax error, unknown field or undefined variable
+	GraphQLQueryLimitExceeded = 1016 // depth or complexity of GraphQL query exceeds limit
+	PersistedQueryNotFound    = 1017 // persisted query with sha256 hash is not registered in allowlist
+	GraphQLQueryNotAllowed    = 1018 // GraphQL query not registered in allowlist is requested in persisted query only mode
+	TooManyEventStreams       = 1019 // user already has max number of event stream connections opened
+	InvalidCursor             = 1020 // cursor of list API is malformed or its signature is invalid
+)
@@ -0,0 +1,134 @@
+// add package in v.1.0.6
+// this package is used to convert json into other formats (MessagePack, Protocol Buffers) supported in gateway & vice versa
+// codec.go is file that declare media types & functions converting body between json and other format
+// json is canonical format in gateway, so response (also cached in redis) is converted from json & request is converted into json
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"reflect"
+)
+
+// media types supported in gateway, protobuf body is google.protobuf.Struct message having fields of json object
+// protobuf is Struct-only (no typed message per API), so every number is encoded as double & body is converted via json
+const (
+	MIMEJSON     = "application/json"
+	MIMEMsgPack  = "application/msgpack"
+	MIMEMsgPack2 = "application/x-msgpack"
+	MIMEProtobuf = "application/x-protobuf"
+)
+
+// Offered is media types in order of preference, json is first to be default format
+var Offered = []string{MIMEJSON, MIMEMsgPack, MIMEMsgPack2, MIMEProtobuf}
+
+var msgpackHandle = &codec.MsgpackHandle{
+	WriteExt: true, // use str & bin type in new spec of MessagePack
+}
+
+func init() {
+	msgpackHandle.RawToString = true
+	msgpackHandle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+}
+
+// return true if media type is supported in gateway
+func IsSupported(mime string) bool {
+	for _, offered := range Offered {
+		if mime == offered {
+			return true
+		}
+	}
+	return false
+}
+
+// return value of Content-Type header of body in media type
+func ContentType(mime string) string {
+	if mime == MIMEJSON {
+		return "application/json; charset=utf-8"
+	}
+	return mime
+}
+
+// convert json body into body of media type, json body is returned as it is if media type is json
+func FromJSON(mime string, body []byte) ([]byte, error) {
+	if mime == MIMEJSON {
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to decode json body, err: %v", err))
+	}
+	value = normalizeNumbers(value)
+
+	switch mime {
+	case MIMEMsgPack, MIMEMsgPack2:
+		var encoded []byte
+		if err := codec.NewEncoderBytes(&encoded, msgpackHandle).Encode(value); err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to encode body into msgpack, err: %v", err))
+		}
+		return encoded, nil
+	case MIMEProtobuf:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("only json object can be encoded into protobuf Struct message")
+		}
+		message, err := structpb.NewStruct(object)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to convert body into protobuf Struct message, err: %v", err))
+		}
+		return proto.Marshal(message)
+	}
+	return nil, errors.New(fmt.Sprintf("%s is an unsupported media type", mime))
+}
+
+// convert body of media type into json body, json body is returned as it is if media type is json
+func ToJSON(mime string, body []byte) ([]byte, error) {
+	var value interface{}
+	switch mime {
+	case MIMEJSON:
+		return body, nil
+	case MIMEMsgPack, MIMEMsgPack2:
+		if err := codec.NewDecoderBytes(body, msgpackHandle).Decode(&value); err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to decode msgpack body, err: %v", err))
+		}
+	case MIMEProtobuf:
+		message := &structpb.Struct{}
+		if err := proto.Unmarshal(body, message); err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to decode protobuf Struct message, err: %v", err))
+		}
+		value = message.AsMap()
+	default:
+		return nil, errors.New(fmt.Sprintf("%s is an unsupported media type", mime))
+	}
+	return json.Marshal(value)
+}
+
+// convert json.Number in value decoded from json into int64 or float64, so number is encoded as number in other format
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	}
+	return value
+}
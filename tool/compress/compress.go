@@ -0,0 +1,136 @@
+// add package in v.1.0.6
+// this package is used to compress response body with encoding (gzip, br) negotiated with Accept-Encoding header
+// compress.go is file that declare function negotiating encoding & compressing body, writers of each encoding are pooled
+
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/andybalholm/brotli"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// encodings supported in gateway, value of Content-Encoding header
+const (
+	Gzip   = "gzip"
+	Brotli = "br"
+)
+
+// supported encodings in order of preference when q values are same
+var supported = []string{Brotli, Gzip}
+
+// level of brotli is lower than default (6), because response is compressed in every request
+const brotliLevel = 4
+
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, brotliLevel) }}
+)
+
+// return supported encoding with highest q value in Accept-Encoding header, empty string if no encoding is acceptable
+// ex) gzip;q=0.8, br -> br, identity -> ""
+func Negotiate(acceptEncoding string) (encoding string) {
+	bestQ := 0.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, q := parseEncoding(part)
+		if q <= bestQ {
+			continue
+		}
+		for _, candidate := range supported {
+			// * matches encoding not listed in header (ex. *, br;q=0 -> gzip)
+			if name == candidate || (name == "*" && !listed(acceptEncoding, candidate)) {
+				encoding, bestQ = candidate, q
+				break
+			}
+		}
+	}
+	return
+}
+
+// return true if encoding is acceptable in Accept-Encoding header
+func Accepts(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		if name, q := parseEncoding(part); name == encoding {
+			return q > 0
+		}
+	}
+	return false
+}
+
+// return true if encoding is listed in Accept-Encoding header regardless of q value
+func listed(acceptEncoding, encoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		if name, _ := parseEncoding(part); name == encoding {
+			return true
+		}
+	}
+	return false
+}
+
+// parse encoding in Accept-Encoding, ex) gzip;q=0.8 -> gzip, 0.8 (q value is 1 if not set or invalid)
+func parseEncoding(part string) (name string, q float64) {
+	params := strings.Split(part, ";")
+	name, q = strings.ToLower(strings.TrimSpace(params[0])), 1
+	for _, param := range params[1:] {
+		if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+			if parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+				q = parsed
+			}
+		}
+	}
+	return
+}
+
+// Writer is writer compressing data written in it, Close must be called to flush compressed data
+type Writer struct {
+	io.WriteCloser
+	release func()
+}
+
+// return writer compressing data into w with encoding, writer is returned to pool in Close
+func NewWriter(encoding string, w io.Writer) (*Writer, error) {
+	switch encoding {
+	case Gzip:
+		gw := gzipWriters.Get().(*gzip.Writer)
+		gw.Reset(w)
+		return &Writer{WriteCloser: gw, release: func() { gzipWriters.Put(gw) }}, nil
+	case Brotli:
+		bw := brotliWriters.Get().(*brotli.Writer)
+		bw.Reset(w)
+		return &Writer{WriteCloser: bw, release: func() { brotliWriters.Put(bw) }}, nil
+	}
+	return nil, errors.New(fmt.Sprintf("%s is an unsupported encoding", encoding))
+}
+
+func (w *Writer) Close() error {
+	defer w.release()
+	return w.WriteCloser.Close()
+}
+
+// return data compressed with encoding
+func Compress(encoding string, data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(encoding, buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// return redis key of response precompressed with gzip, which is set & deleted with key of response
+func PrecompressedKey(key string) string {
+	return key + ".gzip"
+}
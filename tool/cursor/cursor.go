@@ -0,0 +1,114 @@
+// add package in v.1.0.6
+// this package is used to encode position of next page in list API into opaque cursor signed with CURSOR_SECRET_KEY
+// cursor.go is file that declare cursor & functions encoding, decoding cursor and skipping items already responded
+
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// secret key signing cursor, it is loaded explicitly with LoadKeyFromEnv in main instead of init function
+var secretKey []byte
+
+var errKeyNotLoaded = errors.New("secret key of cursor is not loaded, please call LoadKeyFromEnv or SetKey first")
+
+// read secret key of cursor from CURSOR_SECRET_KEY environment variable
+func LoadKeyFromEnv() error {
+	key := os.Getenv("CURSOR_SECRET_KEY")
+	if key == "" {
+		return errors.New("please set CURSOR_SECRET_KEY in environment variable")
+	}
+	SetKey([]byte(key))
+	return nil
+}
+
+// set secret key signing cursor, it must be called before serving requests
+func SetKey(key []byte) {
+	secretKey = key
+}
+
+// Cursor is position of next page, translated into offset (start, count) of list API in service
+type Cursor struct {
+	Start int    `json:"s"`           // offset of next page
+	Count int    `json:"c"`           // number of items in page
+	After string `json:"a,omitempty"` // id of last item in previous page, used to skip items pushed into next page
+	Scope string `json:"sc"`          // hash of list (route, user & filter) cursor is issued for, cursor is rejected in other list
+}
+
+// return cursor encoded into base64 string with hmac-sha256 signature, ex) {payload}.{signature}
+func Encode(c Cursor) (string, error) {
+	if len(secretKey) == 0 {
+		return "", errKeyNotLoaded
+	}
+	payload, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(encoded)), nil
+}
+
+// return cursor decoded from token, error is returned if format or signature of token is invalid
+func Decode(token string) (c Cursor, err error) {
+	if len(secretKey) == 0 {
+		err = errKeyNotLoaded
+		return
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		err = errors.New("cursor must consist of payload and signature separated by dot")
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(parts[0])) {
+		err = errors.New("signature of cursor is invalid")
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to decode payload of cursor, err: %v", err))
+		return
+	}
+	if err = json.Unmarshal(payload, &c); err != nil {
+		err = errors.New(fmt.Sprintf("unable to unmarshal payload of cursor, err: %v", err))
+		return
+	}
+	if c.Start < 0 || c.Count <= 0 {
+		err = errors.New(fmt.Sprintf("start or count of cursor is out of range, start: %d, count: %d", c.Start, c.Count))
+	}
+	return
+}
+
+// return scope of list identified with route, user & filter parameters, which is signed in cursor to be checked in next request
+// parameters must not include ones changing position of page (cursor, start, count) but all ones filtering list
+func Scope(route, userUUID, filter string) string {
+	sum := sha256.Sum256([]byte(route + "\n" + userUUID + "\n" + filter))
+	return hex.EncodeToString(sum[:12])
+}
+
+func sign(payload string) []byte {
+	mac := hmac.New(sha256.New, secretKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// return number of items to skip in front of page, which were already responded in previous page
+// items are pushed back by the number of items inserted in front of list after previous page, so last item of previous page
+// (After) is found in page if less than count items are inserted, 0 is returned if After is not found in page
+func (c Cursor) Skip(ids []string) int {
+	if c.After == "" {
+		return 0
+	}
+	for i, id := range ids {
+		if id == c.After {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+// add package in v.1.0.6
+// this package is used to write response of gateway (status, code, message & payload) as typed envelope recorded in context
+// envelope.go is file that declare envelope & functions writing it, so middlewares read response without parsing body again
+
+package envelope
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/tool/codec"
+	"gateway/tool/compress"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"reflect"
+)
+
+// key of envelope recorded in context by JSON, AbortJSON function
+const contextKey = "ResponseEnvelope"
+
+// Envelope is response of gateway, fields except status, code & message are in Payload
+type Envelope struct {
+	Status  int
+	Code    int
+	Message string
+	Payload gin.H
+	body    []byte // json of envelope written in response body
+}
+
+// return envelope from response having status, code & message fields, error is returned if field is missing or invalid type
+func New(resp gin.H) (e Envelope, err error) {
+	for _, field := range []string{"status", "code", "message"} {
+		if _, ok := resp[field]; !ok {
+			return Envelope{}, errors.New(fmt.Sprintf("json in response body have to contain %s, resp json: %v", field, resp))
+		}
+	}
+	if e.Status, err = toInt("status", resp["status"]); err != nil {
+		return
+	}
+	if e.Code, err = toInt("code", resp["code"]); err != nil {
+		return
+	}
+	msg, ok := resp["message"].(string)
+	if !ok {
+		return Envelope{}, errors.New(fmt.Sprintf("message field should be string type, current type: %s", reflect.TypeOf(resp["message"])))
+	}
+	e.Message = msg
+
+	e.Payload = make(gin.H, len(resp))
+	for key, value := range resp {
+		if key != "status" && key != "code" && key != "message" {
+			e.Payload[key] = value
+		}
+	}
+	return
+}
+
+// convert value of status, code field into int, json number is unmarshalled as float64 & rpc response has int32, uint32
+func toInt(field string, value interface{}) (int, error) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int(v.Float()), nil
+	}
+	return 0, errors.New(fmt.Sprintf("%s field should be converted into int type, current type: %s", field, reflect.TypeOf(value)))
+}
+
+// return response of envelope as map, payload fields are included in same level with status, code & message
+func (e Envelope) Map() gin.H {
+	resp := make(gin.H, len(e.Payload)+3)
+	for key, value := range e.Payload {
+		resp[key] = value
+	}
+	resp["status"], resp["code"], resp["message"] = e.Status, e.Code, e.Message
+	return resp
+}
+
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	if e.body != nil {
+		return e.body, nil
+	}
+	return json.Marshal(e.Map())
+}
+
+// return json of envelope with field added, payload already written in response is not marshalled again
+func (e Envelope) JSONWith(key string, value interface{}) ([]byte, error) {
+	body, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	field, err := json.Marshal(map[string]interface{}{key: value})
+	if err != nil {
+		return nil, err
+	}
+
+	// body & field are json object, so join them by replacing } of body & { of field with comma
+	body = bytes.TrimRight(body, " \n")
+	joined := make([]byte, 0, len(body)+len(field))
+	joined = append(joined, body[:len(body)-1]...)
+	joined = append(joined, ',')
+	return append(joined, field[1:]...), nil
+}
+
+// write response as json of envelope & record envelope in context, response missing status, code or message is 500 error
+// response is encoded into msgpack or protobuf if requested in Accept header, but envelope in context always has json
+// request id, trace id & span id are added in error response to find log & trace with response
+func JSON(c *gin.Context, status int, resp gin.H) {
+	e, err := New(resp)
+	if err != nil {
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	if status >= http.StatusBadRequest {
+		e.Payload["request_id"] = c.GetHeader("X-Request-Id")
+		e.Payload["trace_id"] = c.GetString("TraceID")
+		e.Payload["span_id"] = c.GetString("SpanID")
+	}
+
+	if e.body, err = json.Marshal(e.Map()); err != nil {
+		log.Printf("unable to marshal response into json, err: %v\n", err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	etag := validatorOf(c, status, e.body)
+	c.Set(contextKey, e)
+
+	// successful response is transformed with transformers added in context, envelope in context is not changed
+	body := e.body
+	if HasTransformer(c) && status < http.StatusBadRequest {
+		transformed := e.Map()
+		for _, t := range transformers(c) {
+			t.transform(transformed)
+		}
+		if body, err = json.Marshal(transformed); err != nil {
+			log.Printf("unable to marshal transformed response into json, err: %v\n", err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.Set(transformedKey, body)
+	}
+
+	// json of envelope is encoded into format in Accept header (msgpack, protobuf), json is default format
+	format := c.NegotiateFormat(codec.Offered...)
+	if format == "" {
+		format = codec.MIMEJSON
+	}
+	encoded, err := codec.FromJSON(format, body)
+	if err != nil {
+		log.Printf("unable to encode response into %s, err: %v\n", format, err)
+		format, encoded = codec.MIMEJSON, body
+	}
+	c.Header("Vary", "Accept")
+	setValidators(c, status, etag)
+	c.Data(status, codec.ContentType(format), encoded)
+}
+
+// write body compressed in advance with encoding (ex. gzip response cached in redis) & record envelope of resp in context
+// false is returned without writing if client doesn't accept json or encoding, then response should be written with JSON
+// compressed body must be transformed with transformers in context if exist, ex) body cached with ETag of variant
+func AbortWithPrecompressed(c *gin.Context, status int, resp gin.H, encoding string, compressed []byte) bool {
+	if format := c.NegotiateFormat(codec.Offered...); (format != codec.MIMEJSON && format != "") || !compress.Accepts(c.GetHeader("Accept-Encoding"), encoding) {
+		return false
+	}
+	e, err := New(resp)
+	if err != nil {
+		return false
+	}
+	if e.body, err = json.Marshal(e.Map()); err != nil {
+		return false
+	}
+
+	etag := validatorOf(c, status, e.body)
+
+	c.Abort()
+	c.Set(contextKey, e)
+	c.Header("Vary", "Accept, Accept-Encoding")
+	c.Header("Content-Encoding", encoding)
+	setValidators(c, status, etag)
+	c.Data(status, codec.ContentType(codec.MIMEJSON), compressed)
+	return true
+}
+
+// abort request after writing response in same way as JSON
+func AbortJSON(c *gin.Context, status int, resp gin.H) {
+	c.Abort()
+	JSON(c, status, resp)
+}
+
+// return envelope written in context, false is returned if response is not envelope (ex. file, stream)
+func FromContext(c *gin.Context) (Envelope, bool) {
+	value, ok := c.Get(contextKey)
+	if !ok {
+		return Envelope{}, false
+	}
+	e, ok := value.(Envelope)
+	return e, ok
+}
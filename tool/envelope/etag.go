@@ -0,0 +1,105 @@
+// add file in v.1.0.6
+// etag.go is file that declare functions about conditional GET (ETag, If-None-Match) & Cache-Control header of response
+// ETag is computed from json of envelope, so it is weak ETag shared by all formats (msgpack, protobuf) & encodings (gzip, br)
+// it is computed over canonical json (same with json cached in redis), so ETag sent in cache miss is the same with stored one
+
+package envelope
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// key of Cache-Control directives set in context by SetCacheControl function
+const cacheControlKey = "CacheControl"
+
+// return weak ETag of json body, ex) W/"9f86d081884c7d659a2feaa0c55ad015"
+// it is weak because same ETag is sent with representations of other format & encoding, which are not byte-for-byte equal
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// return redis key of ETag of response, which is set & deleted with key of response
+func ETagKey(key string) string {
+	return key + ".etag"
+}
+
+// set Cache-Control directives of route in context, which is written in header of successful GET response
+func SetCacheControl(c *gin.Context, directives string) {
+	c.Set(cacheControlKey, directives)
+}
+
+// return true if etag matches one of ETags in If-None-Match header, W/ prefix is ignored (weak comparison)
+func MatchETag(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// respond 304 Not Modified without body if etag matches If-None-Match header of GET request
+// false is returned without writing if not matched, then response should be written with JSON
+func NotModified(c *gin.Context, etag string) bool {
+	if c.Request.Method != http.MethodGet || !MatchETag(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+
+	c.Abort()
+	c.Header("ETag", etag)
+	c.Header("Vary", "Accept, Accept-Encoding")
+	setCacheControl(c)
+	c.Status(http.StatusNotModified)
+	return true
+}
+
+// return ETag of json body of successful GET response, empty string is returned for other response or if ETag is set in advance
+// (ex. stored with cache), json is canonicalized so ETag doesn't depend on field order of payload written in handler
+func validatorOf(c *gin.Context, status int, body []byte) string {
+	if c.Request.Method != http.MethodGet || status != http.StatusOK || c.Writer.Header().Get("ETag") != "" {
+		return ""
+	}
+	return ETag(CanonicalJSON(body))
+}
+
+// return json re-encoded after decoding body, which is same with json of response cached in redis (keys sorted, numbers as float64)
+// body is returned as it is if it is not valid json
+func CanonicalJSON(body []byte) []byte {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return body
+	}
+	canonical, err := json.Marshal(decoded)
+	if err != nil {
+		return body
+	}
+	return canonical
+}
+
+// set ETag & Cache-Control header of successful GET response, ETag set in advance (ex. stored with cache) is not computed again
+// etag is ETag of original response, ETag of transformed response is derived from it with variant (change in v.1.0.6)
+func setValidators(c *gin.Context, status int, etag string) {
+	if c.Request.Method != http.MethodGet || status != http.StatusOK {
+		return
+	}
+	if etag != "" && c.Writer.Header().Get("ETag") == "" {
+		c.Header("ETag", VariantETag(etag, Variant(c)))
+	}
+	setCacheControl(c)
+}
+
+func setCacheControl(c *gin.Context) {
+	if directives := c.GetString(cacheControlKey); directives != "" {
+		c.Header("Cache-Control", directives)
+	}
+}
@@ -0,0 +1,38 @@
+package envelope
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// check if ETag sent in cache miss is the same with ETag stored in SetRedisKeyWithResponse, which marshals unmarshalled response
+// payload written in handler keeps field order of struct, but response stored in redis has sorted keys (add in v.1.0.6)
+func TestETagOfCacheMissMatchesStored(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/schedules", nil)
+
+	type schedule struct {
+		Title string  `json:"title"`
+		Date  int64   `json:"date"`
+		Rate  float64 `json:"rate"`
+	}
+	JSON(c, http.StatusOK, gin.H{"status": http.StatusOK, "code": 0, "message": "ok", "schedule": schedule{Title: "b", Date: 1577836800, Rate: 1.50}})
+	sent := w.Header().Get("ETag")
+
+	// same as SetRedisKeyWithResponse, published body is unmarshalled into gin.H & marshalled again
+	e, _ := FromContext(c)
+	body, _ := e.MarshalJSON()
+	resp := gin.H{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("unable to unmarshal response, err: %v", err)
+	}
+	respBytes, _ := json.Marshal(resp)
+	if stored := ETag(CanonicalJSON(respBytes)); sent == "" || sent != stored {
+		t.Errorf("ETag sent in cache miss must be the same with stored one, sent: %s, stored: %s", sent, stored)
+	}
+}
@@ -0,0 +1,89 @@
+// add file in v.1.0.6
+// transformer.go is file that declare functions transforming successful response just before it is written in JSON function
+// envelope recorded in context is not transformed, so response cached in redis or recorded in audit is always original one
+// transformed response has own ETag derived from ETag of original one & variant (parameters of request used in transformers)
+
+package envelope
+
+import (
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// key of transformers added in context by AddTransformer function
+const transformersKey = "ResponseTransformers"
+
+// key of transformer set in context by SetProjection function
+const projectionKey = "ResponseProjection"
+
+// key of json body transformed in JSON function
+const transformedKey = "TransformedResponse"
+
+// Transformer changes fields of response (ex. page of cursor), value of field must be replaced instead of being changed in place
+type Transformer func(resp gin.H)
+
+// variantTransformer is transformer with variant, transformer returns same response if original response & variant are same
+type variantTransformer struct {
+	variant   string
+	transform Transformer
+}
+
+// add transformer applied to successful response written in this context, transformers are applied in order of addition
+// variant is parameters of request used in transformer (ex. cursor=...), which distinguishes ETag of transformed response
+func AddTransformer(c *gin.Context, variant string, t Transformer) {
+	value, _ := c.Get(transformersKey)
+	ts, _ := value.([]variantTransformer)
+	c.Set(transformersKey, append(ts, variantTransformer{variant: variant, transform: t}))
+}
+
+// set transformer projecting response into requested fields, which is applied after all transformers added in context
+// so transformers can read fields not requested (ex. id of item to set next cursor)
+func SetProjection(c *gin.Context, variant string, t Transformer) {
+	c.Set(projectionKey, variantTransformer{variant: variant, transform: t})
+}
+
+// return true if response written in this context is transformed, then precompressed body of original one is not used
+func HasTransformer(c *gin.Context) bool {
+	return len(transformers(c)) != 0
+}
+
+// return variant of response written in this context, empty string is returned if response is not transformed
+func Variant(c *gin.Context) string {
+	ts := transformers(c)
+	variants := make([]string, len(ts))
+	for i, t := range ts {
+		variants[i] = t.variant
+	}
+	return strings.Join(variants, "&")
+}
+
+// return ETag of response transformed with variant, etag is ETag of original response
+// it is computed without transforming response, so 304 Not Modified is responded with ETag of original one stored in redis
+func VariantETag(etag, variant string) string {
+	if variant == "" {
+		return etag
+	}
+	return ETag([]byte(etag + "\n" + variant))
+}
+
+// return json body transformed in JSON function, false is returned if response is not transformed
+func TransformedBody(c *gin.Context) ([]byte, bool) {
+	value, ok := c.Get(transformedKey)
+	if !ok {
+		return nil, false
+	}
+	body, ok := value.([]byte)
+	return body, ok
+}
+
+// return transformers added in context, followed by projection if set
+func transformers(c *gin.Context) []variantTransformer {
+	value, _ := c.Get(transformersKey)
+	ts, _ := value.([]variantTransformer)
+	if value, ok := c.Get(projectionKey); ok {
+		if projection, ok := value.(variantTransformer); ok {
+			ts = append(ts[:len(ts):len(ts)], projection)
+		}
+	}
+	return ts
+}
@@ -0,0 +1,57 @@
+// add package in v.1.0.6
+// this package is used to run GraphQL query on schema which resolvers call services (subset of GraphQL spec for query only)
+// fragment, directive, mutation & subscription are not supported because facade of gateway only read data of services
+// ast.go is file that declare node of parsed GraphQL document
+
+package graphql
+
+// Document is parsed GraphQL request, which can have several operations selected with operation name
+type Document struct {
+	Operations []*Operation
+}
+
+// Operation is query operation with variable definitions & selections of root query object
+type Operation struct {
+	Name       string
+	Variables  []*VariableDefinition
+	Selections []*Selection
+}
+
+// VariableDefinition is declaration of variable in operation, ex. ($count: Int = 10)
+type VariableDefinition struct {
+	Name     string
+	Type     string // type written in query, ex. [String!]!
+	Required bool   // true if type is non-null type
+	Default  Value
+}
+
+// Selection is field selected in selection set, with alias, arguments & sub selections (if field is object type)
+type Selection struct {
+	Alias      string
+	Name       string
+	Arguments  []*Argument
+	Selections []*Selection
+	Line       int
+}
+
+// return key of field in response, which is alias if exists
+func (s *Selection) ResponseKey() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
+	return s.Name
+}
+
+type Argument struct {
+	Name  string
+	Value Value
+}
+
+// Value is literal value in query, which is one of nil, bool, int, float64, string, EnumValue, Variable, []Value, map[string]Value
+type Value interface{}
+
+// EnumValue is name written in place of value without quote, ex. SCHOOL in announcements(type: SCHOOL)
+type EnumValue string
+
+// Variable is reference of variable in place of value, ex. $count
+type Variable string
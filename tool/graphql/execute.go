@@ -0,0 +1,329 @@
+// add file in v.1.0.6
+// execute.go is file that declare executor resolving selections of operation concurrently & building ordered response
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Result is response of GraphQL request, data is nil if request is invalid before execution
+type Result struct {
+	Data   interface{} `json:"data"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is error occurred in request, path is response key path of field which is failed to be resolved
+type Error struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"` // set if error returned from resolver implements extensionsError
+}
+
+// extensionsError is error having additional information (status, code, etc ...) responded in extensions of error
+type extensionsError interface {
+	error
+	Extensions() map[string]interface{}
+}
+
+// ErrQuery is returned from Prepare if query is invalid (syntax, unknown field, undefined variable, etc ...)
+type ErrQuery struct {
+	Message string
+}
+
+func (e ErrQuery) Error() string { return e.Message }
+
+// ErrLimit is returned from Prepare if depth or complexity of query exceeds limit
+type ErrLimit struct {
+	Message string
+}
+
+func (e ErrLimit) Error() string { return e.Message }
+
+// Prepared is operation validated on schema which can be executed
+type Prepared struct {
+	schema *Schema
+	op     *Operation
+	vars   map[string]interface{}
+}
+
+// parse query, select operation with operation name & validate it on schema within limit
+func Prepare(schema *Schema, query, operationName string, variables map[string]interface{}, limit Limit) (*Prepared, error) {
+	doc, err := Parse(query)
+	if err != nil {
+		return nil, ErrQuery{Message: err.Error()}
+	}
+
+	var op *Operation
+	switch {
+	case operationName != "":
+		for _, candidate := range doc.Operations {
+			if candidate.Name == operationName {
+				op = candidate
+			}
+		}
+		if op == nil {
+			return nil, ErrQuery{Message: fmt.Sprintf("unknown operation named %q", operationName)}
+		}
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	default:
+		return nil, ErrQuery{Message: "operation name is required if document contains several operations"}
+	}
+
+	vars := map[string]interface{}{}
+	for _, def := range op.Variables {
+		if value, ok := variables[def.Name]; ok && value != nil {
+			vars[def.Name] = value
+		} else if def.Default != nil {
+			vars[def.Name] = resolveValue(def.Default, nil)
+		} else if def.Required {
+			return nil, ErrQuery{Message: fmt.Sprintf("variable $%s of required type %s was not provided", def.Name, def.Type)}
+		}
+	}
+	if err = checkVariables(op.Selections, op.Variables); err != nil {
+		return nil, ErrQuery{Message: err.Error()}
+	}
+
+	if err = schema.validate(op, vars, limit); err != nil {
+		if _, ok := err.(ErrLimit); ok {
+			return nil, err
+		}
+		return nil, ErrQuery{Message: err.Error()}
+	}
+	return &Prepared{schema: schema, op: op, vars: vars}, nil
+}
+
+// run resolvers of prepared operation, error of resolver is set in errors of result with null value of field
+func (p *Prepared) Execute(ctx context.Context) *Result {
+	e := &executor{ctx: ctx, vars: p.vars}
+	data := e.executeSelections(p.schema.Query, nil, p.op.Selections, nil)
+	return &Result{Data: data, Errors: e.errors}
+}
+
+// check if all variables referred in selections are declared in operation
+func checkVariables(selections []*Selection, defs []*VariableDefinition) error {
+	declared := map[string]bool{}
+	for _, def := range defs {
+		declared[def.Name] = true
+	}
+
+	var check func(value Value) error
+	check = func(value Value) error {
+		switch v := value.(type) {
+		case Variable:
+			if !declared[string(v)] {
+				return errors.New(fmt.Sprintf("variable $%s is not defined", v))
+			}
+		case []Value:
+			for _, elem := range v {
+				if err := check(elem); err != nil {
+					return err
+				}
+			}
+		case map[string]Value:
+			for _, elem := range v {
+				if err := check(elem); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, selection := range selections {
+		for _, arg := range selection.Arguments {
+			if err := check(arg.Value); err != nil {
+				return err
+			}
+		}
+		if err := checkVariables(selection.Selections, defs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convert arguments of selection to map, replacing variable with value of it
+func resolveArguments(args []*Argument, vars map[string]interface{}) map[string]interface{} {
+	resolved := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		resolved[arg.Name] = resolveValue(arg.Value, vars)
+	}
+	return resolved
+}
+
+func resolveValue(value Value, vars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case Variable:
+		return vars[string(v)]
+	case []Value:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = resolveValue(elem, vars)
+		}
+		return list
+	case map[string]Value:
+		object := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			object[key] = resolveValue(elem, vars)
+		}
+		return object
+	}
+	return value
+}
+
+type executor struct {
+	ctx    context.Context
+	vars   map[string]interface{}
+	mutex  sync.Mutex
+	errors []*Error
+}
+
+func (e *executor) addError(err error, path []interface{}) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	gqlErr := &Error{Message: err.Error(), Path: path}
+	if extErr, ok := err.(extensionsError); ok {
+		gqlErr.Extensions = extErr.Extensions()
+	}
+	e.errors = append(e.errors, gqlErr)
+}
+
+// resolve sibling fields concurrently so that loaders can collect keys requested in same level into one batch
+func (e *executor) executeSelections(obj *Object, source interface{}, selections []*Selection, path []interface{}) *orderedMap {
+	result := &orderedMap{keys: make([]string, 0, len(selections)), values: make(map[string]interface{}, len(selections))}
+	values := make([]interface{}, len(selections))
+
+	wg := sync.WaitGroup{}
+	for i, selection := range selections {
+		key := selection.ResponseKey()
+		if _, ok := result.values[key]; !ok {
+			result.keys = append(result.keys, key)
+		}
+		result.values[key] = nil
+
+		if selection.Name == "__typename" {
+			values[i] = obj.Name
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, selection *Selection) {
+			defer wg.Done()
+			values[i] = e.executeField(obj.Fields[selection.Name], source, selection, appendPath(path, selection.ResponseKey()))
+		}(i, selection)
+	}
+	wg.Wait()
+
+	for i, selection := range selections {
+		result.values[selection.ResponseKey()] = values[i]
+	}
+	return result
+}
+
+func (e *executor) executeField(field *Field, source interface{}, selection *Selection, path []interface{}) (value interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			e.addError(errors.New(fmt.Sprintf("panic occurred while resolving field, err: %v", r)), path)
+			value = nil
+		}
+	}()
+
+	var err error
+	if field.Resolve != nil {
+		value, err = field.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: resolveArguments(selection.Arguments, e.vars)})
+	} else if sourceMap, ok := source.(map[string]interface{}); ok {
+		value = sourceMap[selection.Name]
+	}
+	if err != nil {
+		e.addError(err, path)
+		return nil
+	}
+	return e.completeValue(field, value, selection, path)
+}
+
+// execute sub selections on value of object type field, each element is executed concurrently in list field
+func (e *executor) completeValue(field *Field, value interface{}, selection *Selection, path []interface{}) interface{} {
+	if value == nil || (field.Type == nil && !field.List) {
+		return value
+	}
+
+	if !field.List {
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		return e.executeSelections(field.Type, value, selection.Selections, path)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		e.addError(errors.New(fmt.Sprintf("value of list field %q is not list", selection.Name)), path)
+		return nil
+	}
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return []interface{}{}
+	}
+
+	list := make([]interface{}, rv.Len())
+	if field.Type == nil {
+		for i := range list {
+			list[i] = rv.Index(i).Interface()
+		}
+		return list
+	}
+
+	wg := sync.WaitGroup{}
+	for i := range list {
+		item := rv.Index(i).Interface()
+		if item == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, item interface{}) {
+			defer wg.Done()
+			list[i] = e.executeSelections(field.Type, item, selection.Selections, appendPath(path, i))
+		}(i, item)
+	}
+	wg.Wait()
+	return list
+}
+
+// return new path with element appended, not sharing backing array with path of other fields
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	newPath := make([]interface{}, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, elem)
+}
+
+// orderedMap is object in response which keeps order of fields same with selections in query
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		keyBytes, _ := json.Marshal(key)
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		valueBytes, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// error returned from resolver with extensions, same as error of service call in handler
+type testExtensionsError struct{}
+
+func (testExtensionsError) Error() string { return "service is unavailable" }
+func (testExtensionsError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"status": 503}
+}
+
+// return schema of student having outings in test, outings argument count is used as list size
+func testSchema() *Schema {
+	outing := &Object{Name: "Outing"}
+	student := &Object{Name: "Student"}
+	query := &Object{Name: "Query"}
+
+	outing.Fields = map[string]*Field{
+		"outing_uuid": {},
+		"situation":   {},
+	}
+	student.Fields = map[string]*Field{
+		"uuid": {},
+		"name": {},
+		"tags": {List: true},
+		"outings": {
+			Type: outing,
+			List: true,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				count := IntArg(p.Args, "count", 2)
+				outings := make([]interface{}, 0, count)
+				for i := 0; i < count; i++ {
+					outings = append(outings, map[string]interface{}{"outing_uuid": "outing-" + string(rune('a'+i)), "situation": "normal"})
+				}
+				return outings, nil
+			},
+			ListSize: func(args map[string]interface{}) int { return IntArg(args, "count", 2) },
+		},
+		"failing": {Resolve: func(p ResolveParams) (interface{}, error) { return nil, testExtensionsError{} }},
+		"panicking": {Resolve: func(p ResolveParams) (interface{}, error) {
+			var m map[string]interface{}
+			m["panic"] = true
+			return nil, nil
+		}},
+	}
+	query.Fields = map[string]*Field{
+		"student": {
+			Type: student,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				uuid := StringArg(p.Args, "uuid")
+				if uuid == "" {
+					return nil, errors.New("uuid is required")
+				}
+				return map[string]interface{}{"uuid": uuid, "name": "student of " + uuid, "tags": []string{"a", "b"}}, nil
+			},
+		},
+		"students": {
+			Type: student,
+			List: true,
+			Resolve: func(p ResolveParams) (interface{}, error) {
+				var students []interface{}
+				for _, uuid := range StringListArg(p.Args, "uuids") {
+					students = append(students, map[string]interface{}{"uuid": uuid})
+				}
+				return append(students, nil), nil
+			},
+		},
+	}
+	return &Schema{Query: query}
+}
+
+// prepare & execute query on test schema, return json of result
+func executeQuery(t *testing.T, query string, variables map[string]interface{}) string {
+	prepared, err := Prepare(testSchema(), query, "", variables, Limit{})
+	if err != nil {
+		t.Fatalf("valid query must be prepared, query: %s, err: %v", query, err)
+	}
+	b, err := json.Marshal(prepared.Execute(context.Background()))
+	if err != nil {
+		t.Fatalf("unable to marshal result, err: %v", err)
+	}
+	return string(b)
+}
+
+// check if fields are resolved in order of selections with alias, __typename & variables (add in v.1.0.6)
+func TestExecute(t *testing.T) {
+	actual := executeQuery(t, `query ($uuid: String!, $count: Int = 1) {
+		student(uuid: $uuid) { name, __typename, tags, recent: outings(count: $count) { situation outing_uuid } }
+		other: student(uuid: "student-2") { uuid }
+	}`, map[string]interface{}{"uuid": "student-1"})
+
+	expected := `{"data":{"student":{"name":"student of student-1","__typename":"Student","tags":["a","b"],` +
+		`"recent":[{"situation":"normal","outing_uuid":"outing-a"}]},"other":{"uuid":"student-2"}}}`
+	if actual != expected {
+		t.Errorf("result of query is wrong\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+// check if elements of list field are resolved & null element is kept in list
+func TestExecuteList(t *testing.T) {
+	actual := executeQuery(t, `{ students(uuids: ["student-1", "student-2"]) { uuid } }`, nil)
+	expected := `{"data":{"students":[{"uuid":"student-1"},{"uuid":"student-2"},null]}}`
+	if actual != expected {
+		t.Errorf("result of list field is wrong\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
+
+// check if error & panic of resolver are responded in errors with path of field & null value
+func TestExecuteResolverErrors(t *testing.T) {
+	actual := executeQuery(t, `{ student(uuid: "student-1") { failing } broken: student(uuid: "") { uuid } }`, nil)
+	for _, expected := range []string{
+		`"data":{"student":{"failing":null},"broken":null}`,
+		`{"message":"service is unavailable","path":["student","failing"],"extensions":{"status":503}}`,
+		`{"message":"uuid is required","path":["broken"]}`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("result of failed field is wrong, expected: %s, actual: %s", expected, actual)
+		}
+	}
+
+	actual = executeQuery(t, `{ student(uuid: "student-1") { uuid panicking } }`, nil)
+	if !strings.Contains(actual, `"panicking":null`) || !strings.Contains(actual, `"path":["student","panicking"]`) ||
+		!strings.Contains(actual, "panic occurred while resolving field") {
+		t.Errorf("panic in resolver must be responded as error of field, actual: %s", actual)
+	}
+}
+
+// check if invalid query is rejected with ErrQuery before execution
+func TestPrepareErrors(t *testing.T) {
+	for _, tc := range []struct {
+		query, operationName, message string
+		variables                     map[string]interface{}
+	}{
+		{query: `{ student(`, message: "syntax error"},
+		{query: `query A { student(uuid: "a") { uuid } }`, operationName: "B", message: `unknown operation named "B"`},
+		{query: `query A { student(uuid: "a") { uuid } } query B { student(uuid: "b") { uuid } }`, message: "operation name is required"},
+		{query: `query ($uuid: String!) { student(uuid: $uuid) { uuid } }`, message: "variable $uuid of required type String! was not provided"},
+		{query: `query ($uuid: String!) { student(uuid: $uuid) { uuid } }`, variables: map[string]interface{}{"uuid": nil},
+			message: "variable $uuid of required type String! was not provided"},
+		{query: `{ student(uuid: $uuid) { uuid } }`, message: "variable $uuid is not defined"},
+		{query: `{ student(uuid: "a") { outings(filter: {places: [$place]}) { situation } } }`, message: "variable $place is not defined"},
+		{query: `{ teacher { uuid } }`, message: `cannot query field "teacher" on type "Query" (line 1)`},
+		{query: `{ student(uuid: "a") { name { first } } }`, message: `field "name" of scalar type must not have selections`},
+		{query: `{ student(uuid: "a") }`, message: `field "student" of type "Student" must have selections`},
+	} {
+		_, err := Prepare(testSchema(), tc.query, tc.operationName, tc.variables, Limit{})
+		if _, ok := err.(ErrQuery); !ok || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("invalid query must be rejected with ErrQuery, query: %s, expected: %s, actual: %v", tc.query, tc.message, err)
+		}
+	}
+
+	prepared, err := Prepare(testSchema(), `query A { a: student(uuid: "a") { uuid } } query B { b: student(uuid: "b") { uuid } }`,
+		"B", nil, Limit{})
+	if err != nil || prepared.op.Name != "B" {
+		t.Errorf("operation must be selected with operation name, prepared: %+v, err: %v", prepared, err)
+	}
+}
+
+// check if depth & complexity of query are limited, complexity of list field is multiplied by list size
+func TestPrepareLimit(t *testing.T) {
+	query := `{ student(uuid: "a") { uuid outings(count: 5) { situation } } }`
+	// complexity: student(1) + uuid(1) + outings(1 + situation(1) * 5) = 8, depth: 3
+	for _, tc := range []struct {
+		limit    Limit
+		exceeded bool
+	}{
+		{limit: Limit{MaxDepth: 3, MaxComplexity: 8}},
+		{limit: Limit{MaxDepth: 2}, exceeded: true},
+		{limit: Limit{MaxComplexity: 7}, exceeded: true},
+		{limit: Limit{}},
+	} {
+		_, err := Prepare(testSchema(), query, "", nil, tc.limit)
+		if _, isLimitErr := err.(ErrLimit); isLimitErr != tc.exceeded {
+			t.Errorf("query exceeding limit must be rejected with ErrLimit only, limit: %+v, err: %v", tc.limit, err)
+		}
+	}
+
+	// list size without ListSize is DefaultListSize
+	_, err := Prepare(testSchema(), `{ students(uuids: ["a"]) { uuid } }`, "", nil, Limit{MaxComplexity: DefaultListSize})
+	if _, ok := err.(ErrLimit); !ok {
+		t.Errorf("complexity of list field without ListSize must be multiplied by %d, err: %v", DefaultListSize, err)
+	}
+}
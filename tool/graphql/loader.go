@@ -0,0 +1,111 @@
+// add file in v.1.0.6
+// loader.go is file that declare DataLoader collecting keys loaded in short time into one batch call & caching result per key
+
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc load values of keys at once, value of key not in returned map is regarded as null
+type BatchFunc func(ctx context.Context, keys []string) (map[string]interface{}, error)
+
+// Loader should be created per request, because loaded value is cached in loader without expiration
+type Loader struct {
+	batchFunc BatchFunc
+	wait      time.Duration
+	maxBatch  int
+
+	mutex   sync.Mutex
+	cache   map[string]*thunk
+	pending *loaderBatch
+}
+
+// thunk is result of key which is set after batch including the key is dispatched
+type thunk struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+type loaderBatch struct {
+	keys     []string
+	thunks   []*thunk
+	dispatch sync.Once
+}
+
+// return loader dispatching batch after wait since first key is loaded or when keys in batch reach maxBatch
+func NewLoader(batchFunc BatchFunc, wait time.Duration, maxBatch int) *Loader {
+	return &Loader{
+		batchFunc: batchFunc,
+		wait:      wait,
+		maxBatch:  maxBatch,
+		cache:     map[string]*thunk{},
+	}
+}
+
+// return value of key, blocking until batch including the key is dispatched
+func (l *Loader) Load(ctx context.Context, key string) (interface{}, error) {
+	l.mutex.Lock()
+	t, ok := l.cache[key]
+	if !ok {
+		t = &thunk{done: make(chan struct{})}
+		l.cache[key] = t
+
+		if l.pending == nil {
+			l.pending = &loaderBatch{}
+			go func(b *loaderBatch) {
+				time.Sleep(l.wait)
+				l.dispatch(ctx, b)
+			}(l.pending)
+		}
+		b := l.pending
+		b.keys = append(b.keys, key)
+		b.thunks = append(b.thunks, t)
+		if l.maxBatch > 0 && len(b.keys) >= l.maxBatch {
+			go l.dispatch(ctx, b)
+			l.pending = nil
+		}
+	}
+	l.mutex.Unlock()
+
+	select {
+	case <-t.done:
+		return t.value, t.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// return values of keys in order of keys, error of each key is returned in errs
+func (l *Loader) LoadMany(ctx context.Context, keys []string) (values []interface{}, errs []error) {
+	values, errs = make([]interface{}, len(keys)), make([]error, len(keys))
+	wg := sync.WaitGroup{}
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			values[i], errs[i] = l.Load(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+	return
+}
+
+func (l *Loader) dispatch(ctx context.Context, b *loaderBatch) {
+	b.dispatch.Do(func() {
+		l.mutex.Lock()
+		if l.pending == b {
+			l.pending = nil
+		}
+		l.mutex.Unlock()
+
+		values, err := l.batchFunc(ctx, b.keys)
+		for i, key := range b.keys {
+			b.thunks[i].value, b.thunks[i].err = values[key], err
+			close(b.thunks[i].done)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder is BatchFunc recording keys of each batch call, value of key is "value of <key>" except key "missing"
+type batchRecorder struct {
+	mutex   sync.Mutex
+	batches [][]string
+	err     error
+	block   chan struct{} // batch call is blocked until closed if not nil
+}
+
+func (r *batchRecorder) load(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mutex.Lock()
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+	r.batches = append(r.batches, sorted)
+	r.mutex.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	values := map[string]interface{}{}
+	for _, key := range keys {
+		if key != "missing" {
+			values[key] = "value of " + key
+		}
+	}
+	return values, nil
+}
+
+// check if keys loaded in wait are loaded in one batch call & cached in loader (add in v.1.0.6)
+func TestLoaderBatchesKeys(t *testing.T) {
+	recorder := &batchRecorder{}
+	loader := NewLoader(recorder.load, time.Millisecond*20, 0)
+
+	values, errs := loader.LoadMany(context.Background(), []string{"a", "b", "a", "missing"})
+	for i, expected := range []interface{}{"value of a", "value of b", "value of a", nil} {
+		if values[i] != expected || errs[i] != nil {
+			t.Errorf("value of key is wrong, index: %d, expected: %v, actual: %v, err: %v", i, expected, values[i], errs[i])
+		}
+	}
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 3 {
+		t.Fatalf("keys loaded in wait must be loaded in one batch without duplicate, batches: %v", recorder.batches)
+	}
+
+	// cached key is not loaded again
+	if value, err := loader.Load(context.Background(), "b"); value != "value of b" || err != nil {
+		t.Errorf("cached value is wrong, value: %v, err: %v", value, err)
+	}
+	if len(recorder.batches) != 1 {
+		t.Errorf("cached key must not be loaded again, batches: %v", recorder.batches)
+	}
+}
+
+// check if batch is dispatched without waiting when keys reach max batch
+func TestLoaderMaxBatch(t *testing.T) {
+	recorder := &batchRecorder{}
+	loader := NewLoader(recorder.load, time.Second*10, 2)
+
+	done := make(chan struct{})
+	go func() {
+		loader.LoadMany(context.Background(), []string{"a", "b"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("batch reaching max batch must be dispatched before wait")
+	}
+	if len(recorder.batches) != 1 || len(recorder.batches[0]) != 2 {
+		t.Errorf("keys must be loaded in one batch, batches: %v", recorder.batches)
+	}
+}
+
+// check if error of batch call is returned for all keys in batch
+func TestLoaderBatchError(t *testing.T) {
+	recorder := &batchRecorder{err: errors.New("service is unavailable")}
+	loader := NewLoader(recorder.load, time.Millisecond, 0)
+
+	_, errs := loader.LoadMany(context.Background(), []string{"a", "b"})
+	for i, err := range errs {
+		if err != recorder.err {
+			t.Errorf("error of batch call must be returned for key, index: %d, err: %v", i, err)
+		}
+	}
+}
+
+// check if Load returns when context is done before batch call finishes
+func TestLoaderContextDone(t *testing.T) {
+	recorder := &batchRecorder{block: make(chan struct{})}
+	defer close(recorder.block)
+	loader := NewLoader(recorder.load, time.Millisecond, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	if _, err := loader.Load(ctx, "a"); err != context.DeadlineExceeded {
+		t.Errorf("error of done context must be returned, err: %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+// add file in v.1.0.6
+// parser.go is file that declare lexer & recursive descent parser converting GraphQL query string to Document
+
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+type parser struct {
+	source string
+	pos    int
+	line   int
+	token  token
+}
+
+// parse GraphQL query string to Document, return error with line number if query has syntax error
+func Parse(query string) (doc *Document, err error) {
+	p := &parser{source: query, line: 1}
+	if err = p.next(); err != nil {
+		return
+	}
+
+	doc = &Document{}
+	for p.token.kind != tokenEOF {
+		var op *Operation
+		if op, err = p.parseOperation(); err != nil {
+			return nil, err
+		}
+		doc.Operations = append(doc.Operations, op)
+	}
+	if len(doc.Operations) == 0 {
+		return nil, errors.New("document must contain at least one operation")
+	}
+	return
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return errors.New(fmt.Sprintf("syntax error in line %d: %s", p.token.line, fmt.Sprintf(format, args...)))
+}
+
+// read next token from source, skipping white space, comma & comment
+func (p *parser) next() error {
+	for p.pos < len(p.source) {
+		switch ch := p.source[p.pos]; {
+		case ch == '\n':
+			p.line++
+			p.pos++
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == ',':
+			p.pos++
+		case ch == '#':
+			for p.pos < len(p.source) && p.source[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return p.readToken()
+		}
+	}
+	p.token = token{kind: tokenEOF, line: p.line}
+	return nil
+}
+
+func (p *parser) readToken() error {
+	start, ch := p.pos, p.source[p.pos]
+	switch {
+	case strings.HasPrefix(p.source[p.pos:], "..."):
+		p.pos += 3
+		p.token = token{kind: tokenPunctuator, value: "...", line: p.line}
+	case strings.IndexByte("!$():=@[]{}|", ch) >= 0:
+		p.pos++
+		p.token = token{kind: tokenPunctuator, value: string(ch), line: p.line}
+	case ch == '_' || isLetter(ch):
+		for p.pos < len(p.source) && (p.source[p.pos] == '_' || isLetter(p.source[p.pos]) || isDigit(p.source[p.pos])) {
+			p.pos++
+		}
+		p.token = token{kind: tokenName, value: p.source[start:p.pos], line: p.line}
+	case ch == '-' || isDigit(ch):
+		return p.readNumber()
+	case ch == '"':
+		return p.readString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.source[p.pos:])
+		p.token.line = p.line
+		return p.errorf("unexpected character %q", r)
+	}
+	return nil
+}
+
+func (p *parser) readNumber() error {
+	start, kind := p.pos, tokenInt
+	if p.source[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.source) {
+		ch := p.source[p.pos]
+		switch {
+		case isDigit(ch):
+		case ch == '.' || ch == 'e' || ch == 'E' || ((ch == '+' || ch == '-') && kind == tokenFloat):
+			kind = tokenFloat
+		default:
+			p.token = token{kind: kind, value: p.source[start:p.pos], line: p.line}
+			return nil
+		}
+		p.pos++
+	}
+	p.token = token{kind: kind, value: p.source[start:p.pos], line: p.line}
+	return nil
+}
+
+func (p *parser) readString() error {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.source) {
+		switch p.source[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '\n':
+			p.token.line = p.line
+			return p.errorf("unterminated string")
+		case '"':
+			p.pos++
+			value, err := strconv.Unquote(p.source[start:p.pos])
+			if err != nil {
+				p.token.line = p.line
+				return p.errorf("invalid string %s", p.source[start:p.pos])
+			}
+			p.token = token{kind: tokenString, value: value, line: p.line}
+			return nil
+		}
+		p.pos++
+	}
+	p.token.line = p.line
+	return p.errorf("unterminated string")
+}
+
+func isLetter(ch byte) bool { return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') }
+func isDigit(ch byte) bool  { return ch >= '0' && ch <= '9' }
+
+func (p *parser) peek(value string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == value
+}
+
+// read punctuator or return error if current token is not that punctuator
+func (p *parser) expect(value string) error {
+	if !p.peek(value) {
+		return p.errorf("expected %q, found %q", value, p.token.value)
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() (name string, err error) {
+	if p.token.kind != tokenName {
+		return "", p.errorf("expected name, found %q", p.token.value)
+	}
+	name = p.token.value
+	err = p.next()
+	return
+}
+
+func (p *parser) parseOperation() (op *Operation, err error) {
+	op = &Operation{}
+	if p.peek("{") {
+		op.Selections, err = p.parseSelectionSet()
+		return
+	}
+
+	if p.token.kind != tokenName {
+		return nil, p.errorf("expected operation, found %q", p.token.value)
+	}
+	switch p.token.value {
+	case "query":
+	case "mutation", "subscription":
+		return nil, p.errorf("%s operation is not supported", p.token.value)
+	case "fragment":
+		return nil, p.errorf("fragment is not supported")
+	default:
+		return nil, p.errorf("unknown operation type %q", p.token.value)
+	}
+	if err = p.next(); err != nil {
+		return
+	}
+	if p.token.kind == tokenName {
+		op.Name = p.token.value
+		if err = p.next(); err != nil {
+			return
+		}
+	}
+	if p.peek("(") {
+		if op.Variables, err = p.parseVariableDefinitions(); err != nil {
+			return
+		}
+	}
+	if p.peek("@") {
+		return nil, p.errorf("directive is not supported")
+	}
+	op.Selections, err = p.parseSelectionSet()
+	return
+}
+
+func (p *parser) parseVariableDefinitions() (defs []*VariableDefinition, err error) {
+	if err = p.expect("("); err != nil {
+		return
+	}
+	for !p.peek(")") {
+		def := &VariableDefinition{}
+		if err = p.expect("$"); err != nil {
+			return
+		}
+		if def.Name, err = p.expectName(); err != nil {
+			return
+		}
+		if err = p.expect(":"); err != nil {
+			return
+		}
+		if def.Type, err = p.parseType(); err != nil {
+			return
+		}
+		def.Required = strings.HasSuffix(def.Type, "!")
+		if p.peek("=") {
+			if err = p.next(); err != nil {
+				return
+			}
+			if def.Default, err = p.parseValue(true); err != nil {
+				return
+			}
+		}
+		defs = append(defs, def)
+	}
+	err = p.next()
+	return
+}
+
+// parse type of variable & return it as string, ex. [String!]!
+func (p *parser) parseType() (t string, err error) {
+	if p.peek("[") {
+		if err = p.next(); err != nil {
+			return
+		}
+		var elem string
+		if elem, err = p.parseType(); err != nil {
+			return
+		}
+		if err = p.expect("]"); err != nil {
+			return
+		}
+		t = "[" + elem + "]"
+	} else if t, err = p.expectName(); err != nil {
+		return
+	}
+	if p.peek("!") {
+		t += "!"
+		err = p.next()
+	}
+	return
+}
+
+func (p *parser) parseSelectionSet() (selections []*Selection, err error) {
+	if err = p.expect("{"); err != nil {
+		return
+	}
+	for !p.peek("}") {
+		if p.token.kind == tokenEOF {
+			return nil, p.errorf("unexpected end of document in selection set")
+		}
+		if p.peek("...") {
+			return nil, p.errorf("fragment is not supported")
+		}
+		var selection *Selection
+		if selection, err = p.parseSelection(); err != nil {
+			return
+		}
+		selections = append(selections, selection)
+	}
+	if len(selections) == 0 {
+		return nil, p.errorf("selection set must not be empty")
+	}
+	err = p.next()
+	return
+}
+
+func (p *parser) parseSelection() (s *Selection, err error) {
+	s = &Selection{Line: p.token.line}
+	if s.Name, err = p.expectName(); err != nil {
+		return
+	}
+	if p.peek(":") {
+		if err = p.next(); err != nil {
+			return
+		}
+		s.Alias = s.Name
+		if s.Name, err = p.expectName(); err != nil {
+			return
+		}
+	}
+	if p.peek("(") {
+		if err = p.next(); err != nil {
+			return
+		}
+		for !p.peek(")") {
+			arg := &Argument{}
+			if arg.Name, err = p.expectName(); err != nil {
+				return
+			}
+			if err = p.expect(":"); err != nil {
+				return
+			}
+			if arg.Value, err = p.parseValue(false); err != nil {
+				return
+			}
+			s.Arguments = append(s.Arguments, arg)
+		}
+		if err = p.next(); err != nil {
+			return
+		}
+	}
+	if p.peek("@") {
+		return nil, p.errorf("directive is not supported")
+	}
+	if p.peek("{") {
+		s.Selections, err = p.parseSelectionSet()
+	}
+	return
+}
+
+// parse value, variable is not allowed in constant value (default value of variable)
+func (p *parser) parseValue(constant bool) (v Value, err error) {
+	tok := p.token
+	switch {
+	case p.peek("$"):
+		if constant {
+			return nil, p.errorf("variable is not allowed in default value")
+		}
+		if err = p.next(); err != nil {
+			return
+		}
+		var name string
+		name, err = p.expectName()
+		return Variable(name), err
+	case p.peek("["):
+		if err = p.next(); err != nil {
+			return
+		}
+		list := []Value{}
+		for !p.peek("]") {
+			if p.token.kind == tokenEOF {
+				return nil, p.errorf("unexpected end of document in list value")
+			}
+			var elem Value
+			if elem, err = p.parseValue(constant); err != nil {
+				return
+			}
+			list = append(list, elem)
+		}
+		return list, p.next()
+	case p.peek("{"):
+		if err = p.next(); err != nil {
+			return
+		}
+		object := map[string]Value{}
+		for !p.peek("}") {
+			var name string
+			if name, err = p.expectName(); err != nil {
+				return
+			}
+			if err = p.expect(":"); err != nil {
+				return
+			}
+			if object[name], err = p.parseValue(constant); err != nil {
+				return
+			}
+		}
+		return object, p.next()
+	case tok.kind == tokenInt:
+		var i int
+		if i, err = strconv.Atoi(tok.value); err != nil {
+			return nil, p.errorf("invalid int value %s", tok.value)
+		}
+		return i, p.next()
+	case tok.kind == tokenFloat:
+		var f float64
+		if f, err = strconv.ParseFloat(tok.value, 64); err != nil {
+			return nil, p.errorf("invalid float value %s", tok.value)
+		}
+		return f, p.next()
+	case tok.kind == tokenString:
+		return tok.value, p.next()
+	case tok.kind == tokenName:
+		switch tok.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = EnumValue(tok.value)
+		}
+		return v, p.next()
+	}
+	return nil, p.errorf("unexpected %q in place of value", tok.value)
+}
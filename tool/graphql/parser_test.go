@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// check if operation with name, variables, alias, arguments & nested selections is parsed (add in v.1.0.6)
+func TestParseOperation(t *testing.T) {
+	doc, err := Parse(`
+		# query of student dashboard
+		query Dashboard($uuid: String!, $count: Int = 10, $types: [String!]) {
+			student(uuid: $uuid) {
+				name
+				recent: outings(start: 0, count: $count, filter: {situation: EMERGENCY, places: ["home", "hospital"]}) {
+					outing_uuid, situation
+				}
+			}
+		}`)
+	if err != nil {
+		t.Fatalf("valid query must be parsed, err: %v", err)
+	}
+	if len(doc.Operations) != 1 {
+		t.Fatalf("document must have one operation, operations: %d", len(doc.Operations))
+	}
+
+	op := doc.Operations[0]
+	if op.Name != "Dashboard" {
+		t.Errorf("name of operation is wrong, name: %s", op.Name)
+	}
+	expectedVars := []*VariableDefinition{
+		{Name: "uuid", Type: "String!", Required: true},
+		{Name: "count", Type: "Int", Default: 10},
+		{Name: "types", Type: "[String!]"},
+	}
+	if !reflect.DeepEqual(op.Variables, expectedVars) {
+		t.Errorf("variables are parsed wrongly, expected: %+v, actual: %+v", expectedVars, op.Variables)
+	}
+
+	student := op.Selections[0]
+	if student.Name != "student" || student.Line != 4 || !reflect.DeepEqual(student.Arguments, []*Argument{{Name: "uuid", Value: Variable("uuid")}}) {
+		t.Errorf("selection of student is parsed wrongly, selection: %+v", student)
+	}
+	outings := student.Selections[1]
+	if outings.Alias != "recent" || outings.Name != "outings" || outings.ResponseKey() != "recent" {
+		t.Errorf("alias of selection is parsed wrongly, alias: %s, name: %s", outings.Alias, outings.Name)
+	}
+	expectedArgs := []*Argument{
+		{Name: "start", Value: 0},
+		{Name: "count", Value: Variable("count")},
+		{Name: "filter", Value: map[string]Value{"situation": EnumValue("EMERGENCY"), "places": []Value{"home", "hospital"}}},
+	}
+	if !reflect.DeepEqual(outings.Arguments, expectedArgs) {
+		t.Errorf("arguments are parsed wrongly, expected: %+v, actual: %+v", expectedArgs, outings.Arguments)
+	}
+	if len(outings.Selections) != 2 || outings.Selections[1].Name != "situation" {
+		t.Errorf("selections separated by comma are parsed wrongly, selections: %+v", outings.Selections)
+	}
+}
+
+// check if literal values are parsed into golang values
+func TestParseValues(t *testing.T) {
+	doc, err := Parse(`{ field(i: -12, f: 1.5e3, s: "line\n\"quoted\"", t: true, f2: false, n: null, e: SCHOOL, l: [], o: {}) }`)
+	if err != nil {
+		t.Fatalf("valid query must be parsed, err: %v", err)
+	}
+
+	expected := map[string]Value{
+		"i": -12, "f": 1.5e3, "s": "line\n\"quoted\"", "t": true, "f2": false, "n": nil, "e": EnumValue("SCHOOL"),
+		"l": []Value{}, "o": map[string]Value{},
+	}
+	for _, arg := range doc.Operations[0].Selections[0].Arguments {
+		if !reflect.DeepEqual(arg.Value, expected[arg.Name]) {
+			t.Errorf("value of argument is parsed wrongly, argument: %s, expected: %#v, actual: %#v", arg.Name, expected[arg.Name], arg.Value)
+		}
+	}
+}
+
+// check if shorthand query & several named operations are parsed
+func TestParseOperations(t *testing.T) {
+	doc, err := Parse(`query A { a } query B { b }`)
+	if err != nil || len(doc.Operations) != 2 || doc.Operations[0].Name != "A" || doc.Operations[1].Name != "B" {
+		t.Errorf("several named operations must be parsed, doc: %+v, err: %v", doc, err)
+	}
+
+	doc, err = Parse(`{ a }`)
+	if err != nil || len(doc.Operations) != 1 || doc.Operations[0].Name != "" || doc.Operations[0].Selections[0].Name != "a" {
+		t.Errorf("shorthand query must be parsed, doc: %+v, err: %v", doc, err)
+	}
+}
+
+// check if syntax error & unsupported feature are returned as error with line number
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		query, message string
+	}{
+		{query: ``, message: "document must contain at least one operation"},
+		{query: `{ }`, message: "selection set must not be empty"},
+		{query: "{\n  a\n", message: "line 3: unexpected end of document in selection set"},
+		{query: `{ a(b: ) }`, message: `unexpected ")" in place of value`},
+		{query: `{ a(b: "unterminated) }`, message: "unterminated string"},
+		{query: `{ a(b: [1, 2) }`, message: `unexpected ")" in place of value`},
+		{query: `{ a(b: -) }`, message: "invalid int value -"},
+		{query: `{ a % }`, message: "unexpected character '%'"},
+		{query: `query ($a: Int = $b) { a }`, message: "variable is not allowed in default value"},
+		{query: `mutation { a }`, message: "mutation operation is not supported"},
+		{query: `subscription { a }`, message: "subscription operation is not supported"},
+		{query: `fragment f on Query { a }`, message: "fragment is not supported"},
+		{query: `{ ...f }`, message: "fragment is not supported"},
+		{query: `{ a @include(if: true) }`, message: "directive is not supported"},
+		{query: `query @cached { a }`, message: "directive is not supported"},
+		{query: `select { a }`, message: `unknown operation type "select"`},
+	} {
+		if _, err := Parse(tc.query); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("error of invalid query is wrong, query: %q, expected: %s, actual: %v", tc.query, tc.message, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// add file in v.1.0.6
+// persisted.go is file that declare allowlist of persisted queries registered with sha256 hash of query
+
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// PersistedQueries is allowlist of queries, key is hex encoded sha256 hash of query
+type PersistedQueries map[string]string
+
+// read json file which is object of {"<sha256 hash>": "<query>"} & check if hash of each query is correct
+func LoadPersistedQueries(path string) (PersistedQueries, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := PersistedQueries{}
+	if err = json.Unmarshal(b, &queries); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to unmarshal persisted queries file, err: %v", err))
+	}
+	for hash, query := range queries {
+		if Hash(query) != hash {
+			return nil, errors.New(fmt.Sprintf("hash of persisted query is not matched with key %s", hash))
+		}
+	}
+	return queries, nil
+}
+
+// return query registered with hash
+func (q PersistedQueries) Lookup(hash string) (query string, ok bool) {
+	query, ok = q[hash]
+	return
+}
+
+// return true if query is registered in allowlist
+func (q PersistedQueries) Allowed(query string) bool {
+	_, ok := q[Hash(query)]
+	return ok
+}
+
+// return hex encoded sha256 hash of query, which is same with sha256Hash of Apollo automatic persisted query
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+// add file in v.1.0.6
+// schema.go is file that declare object type, field & resolver composing GraphQL schema
+
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// default number of elements assumed in list field when calculating complexity of query
+const DefaultListSize = 10
+
+// ResolveFunc return value of field, which is map[string]interface{} (or slice of it) if type of field is object
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}            // value of parent object, nil in root query
+	Args    map[string]interface{} // arguments of field with variables replaced by value
+}
+
+// Schema is GraphQL schema which has only query root object
+type Schema struct {
+	Query *Object
+}
+
+// Object is object type, fields are set after declaring all objects to refer each other
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is field of object type, value of field is scalar if Type is nil
+type Field struct {
+	Type     *Object
+	List     bool
+	Resolve  ResolveFunc                           // get value of Source with field name if nil
+	ListSize func(args map[string]interface{}) int // return number of elements expected in list field, DefaultListSize if nil
+}
+
+// Limit is maximum depth & complexity of query allowed to be executed, not checked if zero
+// complexity is count of fields to be resolved, multiplied by list size in list field
+type Limit struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// return argument as int, or def if argument isn't set (number in variables is float64 after unmarshalling json)
+func IntArg(args map[string]interface{}, name string, def int) int {
+	switch value := args[name].(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return def
+}
+
+// return argument as string, enum value is also returned as string
+func StringArg(args map[string]interface{}, name string) string {
+	switch value := args[name].(type) {
+	case string:
+		return value
+	case EnumValue:
+		return string(value)
+	}
+	return ""
+}
+
+// return argument as string slice, elements which is not string are skipped
+func StringListArg(args map[string]interface{}, name string) (list []string) {
+	values, _ := args[name].([]interface{})
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			list = append(list, str)
+		}
+	}
+	return
+}
+
+// check if selections of operation are valid on schema & within limit before executing it
+func (s *Schema) validate(op *Operation, vars map[string]interface{}, limit Limit) error {
+	complexity, err := s.validateSelections(s.Query, op.Selections, vars, limit, 1)
+	if err != nil {
+		return err
+	}
+	if limit.MaxComplexity != 0 && complexity > limit.MaxComplexity {
+		return ErrLimit{Message: fmt.Sprintf("complexity of query is %d, which exceeds max complexity %d", complexity, limit.MaxComplexity)}
+	}
+	return nil
+}
+
+func (s *Schema) validateSelections(obj *Object, selections []*Selection, vars map[string]interface{}, limit Limit, depth int) (complexity int, err error) {
+	if limit.MaxDepth != 0 && depth > limit.MaxDepth {
+		return 0, ErrLimit{Message: fmt.Sprintf("depth of query exceeds max depth %d", limit.MaxDepth)}
+	}
+
+	for _, selection := range selections {
+		if selection.Name == "__typename" {
+			complexity++
+			continue
+		}
+		field, ok := obj.Fields[selection.Name]
+		if !ok {
+			return 0, errors.New(fmt.Sprintf("cannot query field %q on type %q (line %d)", selection.Name, obj.Name, selection.Line))
+		}
+		if field.Type == nil && len(selection.Selections) != 0 {
+			return 0, errors.New(fmt.Sprintf("field %q of scalar type must not have selections (line %d)", selection.Name, selection.Line))
+		}
+		if field.Type != nil && len(selection.Selections) == 0 {
+			return 0, errors.New(fmt.Sprintf("field %q of type %q must have selections (line %d)", selection.Name, field.Type.Name, selection.Line))
+		}
+
+		fieldComplexity := 1
+		if field.Type != nil {
+			var childComplexity int
+			if childComplexity, err = s.validateSelections(field.Type, selection.Selections, vars, limit, depth+1); err != nil {
+				return
+			}
+			if field.List {
+				size := DefaultListSize
+				if field.ListSize != nil {
+					size = field.ListSize(resolveArguments(selection.Arguments, vars))
+				}
+				childComplexity *= size
+			}
+			fieldComplexity += childComplexity
+		}
+		complexity += fieldComplexity
+	}
+	return
+}
@@ -0,0 +1,79 @@
+// add file in v.1.0.6
+// catalog.go is file that declare messages of response codes generated in gateway in each language
+// message of code is responded instead of developer message, which is still written in log (code is not changed)
+
+package i18n
+
+import (
+	gwcode "gateway/tool/code"
+	respcode "gateway/utils/code/golang"
+	"net/http"
+)
+
+// Messages is message in each language
+type Messages map[Language]string
+
+// messages of codes used in gateway (utils/code & tool/code)
+var codeMessages = map[int]Messages{
+	respcode.AvailableServiceNotExist:     {Korean: "현재 서비스를 이용할 수 없습니다. 잠시 후 다시 시도해주세요", English: "service is not available now, please try again later"},
+	respcode.CircuitBreakerOpen:           {Korean: "서비스가 일시적으로 응답하지 않습니다. 잠시 후 다시 시도해주세요", English: "service is temporarily unavailable, please try again later"},
+	respcode.ExpiredJWTToken:              {Korean: "로그인이 만료되었습니다. 다시 로그인해주세요", English: "login has expired, please log in again"},
+	respcode.FailToBindRequestToStruct:    {Korean: "요청 형식이 올바르지 않습니다", English: "request format is invalid"},
+	respcode.IntegrityInvalidRequest:      {Korean: "입력한 값이 올바르지 않습니다", English: "some input values are invalid"},
+	respcode.InvalidClaimsOfJWT:           {Korean: "로그인 정보가 올바르지 않습니다. 다시 로그인해주세요", English: "login information is invalid, please log in again"},
+	respcode.InvalidFormatOfAuthorization: {Korean: "인증 정보의 형식이 올바르지 않습니다", English: "format of authorization is invalid"},
+	respcode.InvalidSignatureOfJWT:        {Korean: "로그인 정보가 올바르지 않습니다. 다시 로그인해주세요", English: "login information is invalid, please log in again"},
+	respcode.NoAuthorizationInHeader:      {Korean: "로그인이 필요합니다", English: "login is required"},
+	respcode.UnsupportedAuthorization:     {Korean: "지원하지 않는 인증 방식입니다", English: "authorization method is not supported"},
+	respcode.UnsupportedContentType:       {Korean: "지원하지 않는 요청 형식입니다", English: "content type is not supported"},
+	gwcode.InvalidParentActionToken:       {Korean: "유효하지 않거나 만료된 링크입니다", English: "link is invalid or expired"},
+	gwcode.ConsumedParentActionToken:      {Korean: "이미 사용된 링크입니다", English: "link was already used"},
+	gwcode.TooManyFailedAttempts:          {Korean: "실패 횟수가 너무 많습니다. 잠시 후 다시 시도해주세요", English: "too many failed attempts, please try again later"},
+	gwcode.PartialDashboardSections:       {Korean: "일부 정보를 불러오지 못했습니다", English: "some sections failed to load"},
+	gwcode.InvalidGraphQLQuery:            {Korean: "GraphQL 쿼리가 올바르지 않습니다", English: "GraphQL query is invalid"},
+	gwcode.GraphQLQueryLimitExceeded:      {Korean: "GraphQL 쿼리의 깊이 또는 복잡도가 너무 큽니다", English: "depth or complexity of GraphQL query is too large"},
+	gwcode.PersistedQueryNotFound:         {Korean: "등록되지 않은 쿼리입니다", English: "persisted query is not registered"},
+	gwcode.GraphQLQueryNotAllowed:         {Korean: "허용되지 않은 쿼리입니다", English: "GraphQL query is not allowed"},
+	gwcode.TooManyEventStreams:            {Korean: "동시에 연결할 수 있는 이벤트 스트림 수를 초과했습니다", English: "too many event streams are opened"},
+	gwcode.InvalidCursor:                  {Korean: "목록 위치 정보가 올바르지 않습니다. 처음부터 다시 조회해주세요", English: "cursor is invalid, please request the list from the beginning"},
+}
+
+// messages of status, used in response generated in gateway without detailed code (code 0)
+var statusMessages = map[int]Messages{
+	http.StatusBadRequest:          {Korean: "잘못된 요청입니다", English: "bad request"},
+	http.StatusUnauthorized:        {Korean: "인증에 실패했습니다. 다시 로그인해주세요", English: "authentication failed, please log in again"},
+	http.StatusForbidden:           {Korean: "접근 권한이 없습니다", English: "permission denied"},
+	http.StatusProxyAuthRequired:   {Korean: "프록시를 통해 요청을 보내주세요", English: "please send the request through the proxy"},
+	http.StatusRequestTimeout:      {Korean: "요청 시간이 초과되었습니다. 잠시 후 다시 시도해주세요", English: "request timed out, please try again later"},
+	http.StatusTooManyRequests:     {Korean: "요청이 너무 많습니다. 잠시 후 다시 시도해주세요", English: "too many requests, please try again later"},
+	http.StatusInternalServerError: {Korean: "서버에 오류가 발생했습니다", English: "internal server error occurred"},
+	http.StatusServiceUnavailable:  {Korean: "현재 서비스를 이용할 수 없습니다. 잠시 후 다시 시도해주세요", English: "service is not available now, please try again later"},
+}
+
+// return message of code in lang, message of status is returned if code is not in catalog (ex. 0)
+// false is returned if neither code nor status is in catalog
+func Message(lang Language, status, code int) (string, bool) {
+	if msgs, ok := codeMessages[code]; ok {
+		return msgs.In(lang), true
+	}
+	if msgs, ok := statusMessages[status]; ok {
+		return msgs.In(lang), true
+	}
+	return "", false
+}
+
+// return message of code in language negotiated with Accept-Language header, fallback is returned if not in catalog
+func Localize(acceptLanguage string, status, code int, fallback string) string {
+	if msg, ok := Message(Negotiate(acceptLanguage), status, code); ok {
+		return msg
+	}
+	return fallback
+}
+
+// return message in lang, message in default language is returned if message in lang doesn't exist
+func (m Messages) In(lang Language) string {
+	if msg, ok := m[lang]; ok {
+		return msg
+	}
+	return m[Default]
+}
@@ -0,0 +1,64 @@
+// add package in v.1.0.6
+// this package is used to choose language of message responded by gateway with Accept-Language header
+// language.go is file that declare supported languages & function negotiating language from Accept-Language
+
+package i18n
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Language is language tag of message supported in gateway
+type Language string
+
+const (
+	Korean  Language = "ko"
+	English Language = "en"
+)
+
+// Default is language used if Accept-Language doesn't exist or has no supported language, users of gateway are korean
+const Default = Korean
+
+// supported languages in order of preference when q values are same
+var supported = []Language{Korean, English}
+
+// return supported language with highest q value in Accept-Language header, ex) en-US,en;q=0.9,ko;q=0.8 -> en
+func Negotiate(acceptLanguage string) Language {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseLanguageRange(part)
+		if tag == "" || q <= bestQ {
+			continue
+		}
+		if tag == "*" {
+			best, bestQ = Default, q
+			continue
+		}
+		for _, lang := range supported {
+			if tag == string(lang) || strings.HasPrefix(tag, string(lang)+"-") {
+				best, bestQ = lang, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// parse language range in Accept-Language, ex) en-US;q=0.9 -> en-us, 0.9 (q value is 1 if not set)
+func parseLanguageRange(part string) (tag string, q float64) {
+	params := strings.Split(part, ";")
+	tag, q = strings.ToLower(strings.TrimSpace(params[0])), 1
+	for _, param := range params[1:] {
+		param = strings.TrimSpace(param)
+		if !strings.HasPrefix(param, "q=") {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+	return
+}
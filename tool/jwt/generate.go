@@ -2,18 +2,13 @@ package jwt
 
 import (
 	"github.com/dgrijalva/jwt-go"
-	"log"
-	"os"
 )
 
-var jwtKey string
-func init() {
-	if jwtKey = os.Getenv("JWT_SECRET_KEY"); jwtKey == "" {
-		log.Fatal("please set JWT_SECRET_KEY in environment variable")
-	}
-}
-
 func GenerateStringWithClaims(claims jwt.Claims, method jwt.SigningMethod) (ss string, err error) {
+	if jwtKey == "" { // add in v.1.0.6
+		err = errKeyNotLoaded
+		return
+	}
 	ss, err = jwt.NewWithClaims(method, claims).SignedString([]byte(jwtKey))
 	return
 }
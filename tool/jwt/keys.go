@@ -0,0 +1,42 @@
+// add file in v.1.0.6
+// keys.go is file that declare secret keys signing tokens, which were read from environment variable in init function
+// keys are loaded explicitly with LoadKeysFromEnv in main, so that importing this package doesn't require environment variable
+
+package jwt
+
+import (
+	"errors"
+	"os"
+)
+
+var (
+	jwtKey          string
+	parentActionKey string
+)
+
+// error returned from functions generating or parsing token if secret keys are not loaded yet
+var errKeyNotLoaded = errors.New("secret key of token is not loaded, please call LoadKeysFromEnv or SetKeys first")
+
+// read secret keys from JWT_SECRET_KEY & PARENT_ACTION_SECRET_KEY environment variable
+func LoadKeysFromEnv() error {
+	if os.Getenv("JWT_SECRET_KEY") == "" {
+		return errors.New("please set JWT_SECRET_KEY in environment variable")
+	}
+	if os.Getenv("PARENT_ACTION_SECRET_KEY") == "" {
+		return errors.New("please set PARENT_ACTION_SECRET_KEY in environment variable")
+	}
+	return SetKeys(os.Getenv("JWT_SECRET_KEY"), os.Getenv("PARENT_ACTION_SECRET_KEY"))
+}
+
+// set secret keys signing access token & parent action token, it must be called before serving requests
+// parent action key must be different from jwt key so that parent action token can't be used as access token
+func SetKeys(jwtSecret, parentActionSecret string) error {
+	if jwtSecret == "" || parentActionSecret == "" {
+		return errors.New("secret keys of token must not be blank string")
+	}
+	if jwtSecret == parentActionSecret {
+		return errors.New("PARENT_ACTION_SECRET_KEY must be different from JWT_SECRET_KEY")
+	}
+	jwtKey, parentActionKey = jwtSecret, parentActionSecret
+	return nil
+}
@@ -0,0 +1,50 @@
+// add file in v.1.0.6
+// parent_action_claim.go is file that declare claims of token in link sent to parent to approve or reject outing
+// token is signed with key different from JWT_SECRET_KEY so that it can't be used as access token (UUIDClaims)
+
+package jwt
+
+import (
+	"errors"
+	"github.com/dgrijalva/jwt-go"
+)
+
+// ParentActionClaims is bound to outing, parent & action, Id (jti) is opaque key of confirm code saved in server until consumed
+// confirm code must not be in claims because payload of token is only base64-encoded and link is sent in SMS
+type ParentActionClaims struct {
+	OutingUUID string `json:"outing_uuid"`
+	ParentUUID string `json:"parent_uuid"`
+	Action     string `json:"action"`
+	jwt.StandardClaims
+}
+
+func GenerateParentActionString(claims ParentActionClaims) (ss string, err error) {
+	if parentActionKey == "" {
+		err = errKeyNotLoaded
+		return
+	}
+	ss, err = jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte(parentActionKey))
+	return
+}
+
+func ParseParentActionClaimsFrom(tokenStr string) (claims *ParentActionClaims, err error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &ParentActionClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS512 {
+			return nil, errors.New("unexpected signing method of parent action token")
+		}
+		if parentActionKey == "" {
+			return nil, errKeyNotLoaded
+		}
+		return []byte(parentActionKey), nil
+	})
+	if err != nil {
+		return
+	}
+
+	claims, ok := token.Claims.(*ParentActionClaims)
+	if !ok || !token.Valid {
+		err = errors.New("that token is invalid for ParentActionClaims")
+		return
+	}
+	return
+}
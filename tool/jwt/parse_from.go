@@ -7,6 +7,9 @@ import (
 
 func ParseUUIDClaimsFrom(tokenStr string) (claims *UUIDClaims, err error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &UUIDClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if jwtKey == "" { // add in v.1.0.6
+			return nil, errKeyNotLoaded
+		}
 		return []byte(jwtKey), nil
 	})
 	if err != nil {
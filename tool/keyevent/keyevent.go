@@ -0,0 +1,36 @@
+// add package in v.1.0.6
+// this package is used to format & parse message of delete redis key event published in DeleteKeyEventPublisher
+// keyevent.go is file that declare functions formatting key with request id & user uuid of request changing resource
+// message with request id & user uuid is published on own topic, so gateway before v.1.0.6 still receives only key in delete topic
+
+package keyevent
+
+import (
+	"strings"
+)
+
+// separator of fields in message, redis key never includes space
+const separator = " "
+
+// return topic of delete redis key event messages, which is derived from delete topic, ex) redis-delete-topic.events
+func Topic(delTopic string) string {
+	return delTopic + ".events"
+}
+
+// return message of delete redis key event, ex) outings.outing-123412341234 {X-Request-Id} teacher-123412341234
+func Message(redisKey, reqID, userUUID string) string {
+	return strings.Join([]string{redisKey, reqID, userUUID}, separator)
+}
+
+// return redis key, request id & user uuid in message, empty string is returned for missing field
+func Parse(msg string) (redisKey, reqID, userUUID string) {
+	fields := strings.SplitN(msg, separator, 3)
+	redisKey = fields[0]
+	if len(fields) > 1 {
+		reqID = fields[1]
+	}
+	if len(fields) > 2 {
+		userUUID = fields[2]
+	}
+	return
+}
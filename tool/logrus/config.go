@@ -0,0 +1,75 @@
+// add file in v.1.0.6
+// config.go is file that declare configuration of logger read from environment variable per logger group
+
+package logrus
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// sink names used in LOG_SINK environment variable, multiple sinks can be set with comma (ex. stdout,logstash)
+const (
+	StdoutSink   = "stdout"   // write json log in standard output to be collected in k8s
+	FileSink     = "file"     // write json log in rotated file (ex. file read from filebeat)
+	LogstashSink = "logstash" // ship json log to logstash with TCP directly
+)
+
+// drop policy names used in LOGSTASH_DROP_POLICY, decide what to do if buffer of logstash writer is full
+const (
+	DropNewestPolicy = "drop_newest" // drop log being written now
+	DropOldestPolicy = "drop_oldest" // drop the oldest log in buffer & push log being written now
+	BlockPolicy      = "block"       // wait until buffer has space
+)
+
+type config struct {
+	sinks []string
+	level string
+
+	fileDir        string
+	fileMaxSize    int // megabytes
+	fileMaxAge     int // days
+	fileMaxBackups int
+	fileCompress   bool
+
+	logstashAddress    string
+	logstashBufferSize int
+	logstashDropPolicy string
+}
+
+// read configuration of group from environment variable, value for group (ex. LOG_SINK_OPEN_API) has priority over default one
+func configFromEnv(group string) config {
+	return config{
+		sinks:              strings.Split(groupEnv(group, "LOG_SINK", FileSink), ","),
+		level:              groupEnv(group, "LOG_LEVEL", "info"),
+		fileDir:            groupEnv(group, "LOG_FILE_DIR", "/usr/share/filebeat/log/dms-sms"),
+		fileMaxSize:        groupIntEnv(group, "LOG_FILE_MAX_SIZE_MB", 100),
+		fileMaxAge:         groupIntEnv(group, "LOG_FILE_MAX_AGE_DAYS", 7),
+		fileMaxBackups:     groupIntEnv(group, "LOG_FILE_MAX_BACKUPS", 5),
+		fileCompress:       groupEnv(group, "LOG_FILE_COMPRESS", "false") == "true",
+		logstashAddress:    groupEnv(group, "LOGSTASH_ADDRESS", ""),
+		logstashBufferSize: groupIntEnv(group, "LOGSTASH_BUFFER_SIZE", 1024),
+		logstashDropPolicy: groupEnv(group, "LOGSTASH_DROP_POLICY", DropNewestPolicy),
+	}
+}
+
+// return value of {key}_{GROUP} or {key} environment variable, def if both not exist
+func groupEnv(group, key, def string) string {
+	groupKey := key + "_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(group))
+	if value, ok := os.LookupEnv(groupKey); ok && value != "" {
+		return value
+	}
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return def
+}
+
+func groupIntEnv(group, key string, def int) int {
+	value, err := strconv.Atoi(groupEnv(group, key, strconv.Itoa(def)))
+	if err != nil {
+		return def
+	}
+	return value
+}
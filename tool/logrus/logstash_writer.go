@@ -0,0 +1,171 @@
+// add file in v.1.0.6
+// logstash_writer.go is file that declare writer shipping log to logstash with TCP asynchronously through buffer
+
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	logstashDialTimeout  = time.Second * 3
+	logstashWriteTimeout = time.Second * 3
+	logstashRetryDelay   = time.Second * 3
+)
+
+// logstashWriter write log in buffered channel & ship it to logstash in another goroutine so that request is not blocked
+// by logstash connection, log is handled with drop policy if buffer is full
+type logstashWriter struct {
+	address    string
+	dropPolicy string
+	buffer     chan []byte
+	dropped    uint64
+	closed     uint32
+	closing    chan struct{}
+	closeOnce  sync.Once
+	done       chan struct{}
+}
+
+func newLogstashWriter(address string, bufferSize int, dropPolicy string) (*logstashWriter, error) {
+	switch dropPolicy {
+	case DropNewestPolicy, DropOldestPolicy, BlockPolicy:
+	default:
+		return nil, errors.New(fmt.Sprintf("%s is an unsupported drop policy", dropPolicy))
+	}
+	if bufferSize <= 0 {
+		return nil, errors.New(fmt.Sprintf("buffer size of logstash writer must be positive, size: %d", bufferSize))
+	}
+
+	w := &logstashWriter{
+		address:    address,
+		dropPolicy: dropPolicy,
+		buffer:     make(chan []byte, bufferSize),
+		closing:    make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go w.ship()
+	return w, nil
+}
+
+// p is copied because logrus reuse buffer of formatted entry after Write return
+// buffer channel is never closed, so Write blocked by full buffer with BlockPolicy is released by closing channel in Close
+func (w *logstashWriter) Write(p []byte) (n int, err error) {
+	if atomic.LoadUint32(&w.closed) == 1 {
+		return 0, errors.New("logstash writer is already closed")
+	}
+
+	msg := make([]byte, len(p))
+	copy(msg, p)
+
+	switch w.dropPolicy {
+	case BlockPolicy:
+		select {
+		case w.buffer <- msg:
+		case <-w.closing:
+			return 0, errors.New("logstash writer is already closed")
+		}
+	case DropOldestPolicy:
+		for {
+			select {
+			case w.buffer <- msg:
+				return len(p), nil
+			default:
+			}
+			select {
+			case <-w.buffer:
+				atomic.AddUint64(&w.dropped, 1)
+			default:
+			}
+		}
+	default:
+		select {
+		case w.buffer <- msg:
+		default:
+			atomic.AddUint64(&w.dropped, 1)
+		}
+	}
+	return len(p), nil
+}
+
+// send log in buffer to logstash, reconnect if connection is closed & retry sending the log failed to send
+// after Close is called, logs remaining in buffer are shipped without waiting for new log
+func (w *logstashWriter) ship() {
+	defer close(w.done)
+
+	var conn net.Conn
+	var retry []byte
+	for {
+		msg := retry
+		if msg == nil {
+			select {
+			case msg = <-w.buffer:
+			case <-w.closing:
+				select {
+				case msg = <-w.buffer:
+				default:
+				}
+			}
+			if msg == nil {
+				break
+			}
+		}
+
+		if conn == nil {
+			var err error
+			if conn, err = net.DialTimeout("tcp", w.address, logstashDialTimeout); err != nil {
+				log.Printf("unable to connect to logstash, address: %s, err: %v\n", w.address, err)
+				conn, retry = nil, msg
+				// stop retrying once writer is closed, logs not shipped yet are dropped
+				select {
+				case <-time.After(logstashRetryDelay):
+				case <-w.closing:
+					log.Printf("logstash writer is closed, drop logs not shipped, address: %s\n", w.address)
+					return
+				}
+				continue
+			}
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(logstashWriteTimeout))
+		if _, err := conn.Write(msg); err != nil {
+			log.Printf("unable to write log to logstash, address: %s, err: %v\n", w.address, err)
+			_ = conn.Close()
+			conn, retry = nil, msg
+			continue
+		}
+		retry = nil
+	}
+
+	if conn != nil {
+		_ = conn.Close()
+	}
+}
+
+// return number of logs dropped because buffer was full
+func (w *logstashWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// stop receiving log & wait until logs remaining in buffer are shipped, writers blocked by full buffer are not waited
+func (w *logstashWriter) Close() error {
+	w.closeOnce.Do(func() {
+		atomic.StoreUint32(&w.closed, 1)
+		close(w.closing)
+	})
+
+	select {
+	case <-w.done:
+	case <-time.After(logstashWriteTimeout * 2):
+		return errors.New(fmt.Sprintf("timeout while flushing logs in buffer to logstash, address: %s", w.address))
+	}
+	if dropped := w.Dropped(); dropped != 0 {
+		log.Printf("%d logs were dropped in logstash writer because buffer was full, address: %s\n", dropped, w.address)
+	}
+	return nil
+}
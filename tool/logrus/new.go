@@ -8,26 +8,33 @@ import (
 	"github.com/sirupsen/logrus"
 	"io"
 	"log"
-	"os"
 )
 
-type noneWriter struct {
-	io.Writer
-}
+// create logger of group (auth, club, outing, ...) writing log in logstash json format to sinks set in environment variable
+// sink, level of logger is decided by LOG_SINK_{GROUP}, LOG_LEVEL_{GROUP} or LOG_SINK, LOG_LEVEL if not exist (change in v.1.0.6)
+func New(group string, fields logrus.Fields) (logger *logrus.Logger) {
+	cfg := configFromEnv(group)
 
-func (n noneWriter) Write(p []byte) (_ int, _ error) {
-	return
-}
+	var writers []io.Writer
+	for _, sink := range cfg.sinks {
+		writer, err := newSinkWriter(sink, group, cfg)
+		if err != nil {
+			log.Fatalf("unable to create log sink, group: %s, sink: %s, err: %v\n", group, sink, err)
+			return
+		}
+		writers = append(writers, writer)
+	}
 
-func New(filepath string, fields logrus.Fields) (logger *logrus.Logger) {
-	logfile, err := os.OpenFile(filepath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0755)
+	level, err := logrus.ParseLevel(cfg.level)
 	if err != nil {
-		log.Fatalf("unable to open file, err: %v\n", err)
+		log.Fatalf("unable to parse log level, group: %s, level: %s, err: %v\n", group, cfg.level, err)
 		return
 	}
 
 	logger = logrus.New()
-	logger.SetOutput(noneWriter{})
-	logger.Hooks.Add(logrustash.New(logfile, logrustash.DefaultFormatter(fields)))
+	logger.SetOutput(io.MultiWriter(writers...))
+	logger.SetFormatter(logrustash.DefaultFormatter(fields))
+	logger.SetLevel(level)
+	globalLoggers.register(group, logger, writers)
 	return
 }
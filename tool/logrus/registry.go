@@ -0,0 +1,101 @@
+// add file in v.1.0.6
+// registry.go is file that save all logger created in New function per group to change log level at runtime & close sinks
+
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+var globalLoggers = &loggerRegistry{loggers: map[string]*logrus.Logger{}}
+
+type loggerRegistry struct {
+	loggers map[string]*logrus.Logger
+	closers []io.Closer
+	mutex   sync.RWMutex
+}
+
+func (r *loggerRegistry) register(group string, logger *logrus.Logger, writers []io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.loggers[group] = logger
+	for _, writer := range writers {
+		// standard streams of process are not opened by sink, so they must not be closed with sinks
+		if writer == os.Stdout || writer == os.Stderr {
+			continue
+		}
+		if closer, ok := writer.(io.Closer); ok {
+			r.closers = append(r.closers, closer)
+		}
+	}
+}
+
+// return log level of all logger groups
+func Levels() map[string]string {
+	globalLoggers.mutex.RLock()
+	defer globalLoggers.mutex.RUnlock()
+
+	levels := make(map[string]string, len(globalLoggers.loggers))
+	for group, logger := range globalLoggers.loggers {
+		levels[group] = logger.GetLevel().String()
+	}
+	return levels
+}
+
+// return name of all logger groups in ascending order
+func Groups() (groups []string) {
+	globalLoggers.mutex.RLock()
+	defer globalLoggers.mutex.RUnlock()
+
+	for group := range globalLoggers.loggers {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return
+}
+
+// change log level of logger group, change all logger groups if group is empty string
+func SetLevel(group, level string) error {
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	globalLoggers.mutex.RLock()
+	defer globalLoggers.mutex.RUnlock()
+
+	if group == "" {
+		for _, logger := range globalLoggers.loggers {
+			logger.SetLevel(parsedLevel)
+		}
+		return nil
+	}
+
+	logger, ok := globalLoggers.loggers[group]
+	if !ok {
+		return errors.New(fmt.Sprintf("logger group %s does not exist", group))
+	}
+	logger.SetLevel(parsedLevel)
+	return nil
+}
+
+// close all sinks of loggers (rotated file, logstash connection), flushing logs remaining in buffer
+func Close() (err error) {
+	globalLoggers.mutex.Lock()
+	defer globalLoggers.mutex.Unlock()
+
+	for _, closer := range globalLoggers.closers {
+		if closeErr := closer.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	globalLoggers.closers = nil
+	return
+}
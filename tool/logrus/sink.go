@@ -0,0 +1,40 @@
+// add file in v.1.0.6
+// sink.go is file that declare writers of each log sink (stdout, rotated file, logstash)
+
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// return writer of sink, returned writer have to be closed in Close function if it implement io.Closer
+func newSinkWriter(sink, group string, cfg config) (io.Writer, error) {
+	switch strings.TrimSpace(sink) {
+	case StdoutSink:
+		return os.Stdout, nil
+	case FileSink:
+		if err := os.MkdirAll(cfg.fileDir, os.ModePerm); err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to make log directory, dir: %s, err: %v", cfg.fileDir, err))
+		}
+		return &lumberjack.Logger{
+			Filename:   filepath.Join(cfg.fileDir, group+".log"),
+			MaxSize:    cfg.fileMaxSize,
+			MaxAge:     cfg.fileMaxAge,
+			MaxBackups: cfg.fileMaxBackups,
+			Compress:   cfg.fileCompress,
+		}, nil
+	case LogstashSink:
+		if cfg.logstashAddress == "" {
+			return nil, errors.New("LOGSTASH_ADDRESS environment variable must be set to use logstash sink")
+		}
+		return newLogstashWriter(cfg.logstashAddress, cfg.logstashBufferSize, cfg.logstashDropPolicy)
+	default:
+		return nil, errors.New(fmt.Sprintf("%s is an unsupported log sink", sink))
+	}
+}
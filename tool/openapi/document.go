@@ -0,0 +1,96 @@
+// add package in v.1.0.6
+// this package is used to build OpenAPI 3 document from request entity struct & serve it with Swagger UI
+// document.go is file that declare objects composing OpenAPI 3 document (only fields used in gateway)
+
+package openapi
+
+// Version is version of OpenAPI specification which document follows
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"` // key of inner map is lower case http method
+	Components Components                       `json:"components"`
+	Security   []SecurityRequirement            `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityRequirement is map of security scheme name to scopes (always empty in gateway)
+type SecurityRequirement map[string][]string
+
+type SecurityScheme struct {
+	Type         string `json:"type"`                   // apiKey, http
+	Name         string `json:"name,omitempty"`         // header name if type is apiKey
+	In           string `json:"in,omitempty"`           // header if type is apiKey
+	Scheme       string `json:"scheme,omitempty"`       // bearer if type is http
+	BearerFormat string `json:"bearerFormat,omitempty"` // JWT if scheme is bearer
+	Description  string `json:"description,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"` // key is status code or default
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query, header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"` // key is content type
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Schema is subset of OpenAPI schema object, Ref is set alone if schema refers component
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // bool or *Schema
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// return schema referring component schema with name
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
@@ -0,0 +1,251 @@
+// add file in v.1.0.6
+// schema.go is file that declare function converting struct to schema with field name from binding tag (json, form, uri)
+// and constraints from validate tag, including custom validation (uuid, int_range, values, int_len, etc ...) of entity/validator
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime/multipart"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// binding tag keys of gin, field not having tag of binding is not included in schema
+const (
+	TagJSON = "json"
+	TagForm = "form"
+	TagURI  = "uri"
+)
+
+var (
+	fileHeaderType  = reflect.TypeOf(multipart.FileHeader{})
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	validateTagKey  = "validate"
+	defaultTagKey   = "default"      // value set in field not sent in request by middleware.RequestValidator
+	uuidPatternForm = "^%s-\\d{12}$" // same format with uuid regexes in entity/validator
+)
+
+// Field is field of struct with name in binding tag, schema & whether field is required in validate tag
+type Field struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// return fields of struct type having binding tag with tagKey, fields of embedded struct without tag are flattened
+func StructFields(t reflect.Type, tagKey string) (fields []Field) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup(tagKey)
+		if sf.Anonymous && !tagged {
+			fields = append(fields, StructFields(sf.Type, tagKey)...)
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if !tagged || name == "-" || name == "" || sf.PkgPath != "" {
+			continue
+		}
+
+		schema := TypeSchema(sf.Type, tagKey)
+		required := applyValidateTag(schema, sf.Tag.Get(validateTagKey))
+		if def, ok := sf.Tag.Lookup(defaultTagKey); ok {
+			schema.Default = enumValues(schema, []string{def})[0]
+		}
+		fields = append(fields, Field{Name: name, Schema: schema, Required: required})
+	}
+	return
+}
+
+// return schema of type, properties of struct type are read with StructFields
+func TypeSchema(t reflect.Type, tagKey string) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{Description: "any json value"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: float64Ptr(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: TypeSchema(t.Elem(), tagKey)}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object", AdditionalProperties: true}
+		}
+		return &Schema{Type: "object", AdditionalProperties: TypeSchema(t.Elem(), tagKey)}
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, field := range StructFields(t, tagKey) {
+			schema.Properties[field.Name] = field.Schema
+			if field.Required {
+				schema.Required = append(schema.Required, field.Name)
+			}
+		}
+		return schema
+	}
+	return &Schema{}
+}
+
+// set constraints in validate tag to schema & return true if field is required
+// rules after dive are applied to items of array schema, rules not expressible in schema are written in description
+func applyValidateTag(schema *Schema, tag string) (required bool) {
+	if tag == "" {
+		return
+	}
+
+	var undocumented []string
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		name, param := rule, ""
+		if sep := strings.Index(rule, "="); sep != -1 {
+			name, param = rule[:sep], rule[sep+1:]
+		}
+
+		switch name {
+		case "required":
+			required = true
+		case "omitempty":
+		case "dive":
+			if schema.Items != nil {
+				applyValidateTag(schema.Items, strings.Join(rules[i+1:], ","))
+			}
+			return
+		case "min", "max", "len":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				undocumented = append(undocumented, rule)
+				continue
+			}
+			if name != "max" {
+				setLowerBound(schema, n)
+			}
+			if name != "min" {
+				setUpperBound(schema, n)
+			}
+		case "int_range":
+			bounds := strings.Split(param, "~")
+			if len(bounds) != 2 {
+				undocumented = append(undocumented, rule)
+				continue
+			}
+			start, startErr := strconv.ParseFloat(bounds[0], 64)
+			end, endErr := strconv.ParseFloat(bounds[1], 64)
+			if startErr != nil || endErr != nil {
+				undocumented = append(undocumented, rule)
+				continue
+			}
+			schema.Minimum, schema.Maximum = float64Ptr(start), float64Ptr(end)
+		case "int_len":
+			n, err := strconv.Atoi(param)
+			if err != nil || n <= 0 {
+				undocumented = append(undocumented, rule)
+				continue
+			}
+			lower := math.Pow10(n - 1)
+			if n == 1 {
+				lower = 0
+			}
+			schema.Minimum, schema.Maximum = float64Ptr(lower), float64Ptr(math.Pow10(n)-1)
+		case "values":
+			schema.Enum = enumValues(schema, strings.Split(param, "&"))
+		case "oneof":
+			schema.Enum = enumValues(schema, strings.Fields(param))
+		case "uuid":
+			schema.Pattern = fmt.Sprintf(uuidPatternForm, param)
+		case "phone_number":
+			schema.Pattern = "^010\\d{8}$"
+		case "time":
+			schema.Pattern, schema.Format = "^\\d{4}-\\d{2}-\\d{2}", "date"
+		case "startswith":
+			schema.Pattern = "^" + regexp.QuoteMeta(param)
+		case "hexadecimal":
+			schema.Pattern = "^[0-9a-fA-F]+$"
+		case "email":
+			schema.Format = "email"
+		default:
+			undocumented = append(undocumented, rule)
+		}
+	}
+
+	if len(undocumented) != 0 {
+		schema.Description = strings.TrimSpace(fmt.Sprintf("%s validate: %s", schema.Description, strings.Join(undocumented, ",")))
+	}
+	return
+}
+
+// set minimum length, value or number of items according to type of schema
+func setLowerBound(schema *Schema, n float64) {
+	switch schema.Type {
+	case "string":
+		schema.MinLength = intPtr(int(n))
+	case "array":
+		schema.MinItems = intPtr(int(n))
+	default:
+		schema.Minimum = float64Ptr(n)
+	}
+}
+
+// set maximum length, value or number of items according to type of schema
+func setUpperBound(schema *Schema, n float64) {
+	switch schema.Type {
+	case "string":
+		schema.MaxLength = intPtr(int(n))
+	case "array":
+		schema.MaxItems = intPtr(int(n))
+	default:
+		schema.Maximum = float64Ptr(n)
+	}
+}
+
+// convert enum values to number if type of schema is integer or number
+func enumValues(schema *Schema, values []string) (enum []interface{}) {
+	for _, value := range values {
+		if schema.Type == "integer" || schema.Type == "number" {
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				enum = append(enum, n)
+				continue
+			}
+		}
+		enum = append(enum, value)
+	}
+	return
+}
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func intPtr(i int) *int { return &i }
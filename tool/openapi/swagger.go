@@ -0,0 +1,60 @@
+// add file in v.1.0.6
+// swagger.go is file that declare html page rendering document with Swagger UI & assets of Swagger UI served by gateway
+// assets are compiled into binary from github.com/swaggo/files, so page doesn't depend on CDN (change in v.1.0.6)
+
+package openapi
+
+import (
+	"fmt"
+	swaggerFiles "github.com/swaggo/files"
+	"html"
+	"strings"
+)
+
+// SwaggerUIAsset is static file of Swagger UI loaded in page
+type SwaggerUIAsset struct {
+	ContentType string
+	Body        []byte
+}
+
+// assets of Swagger UI loaded in page, key is file name used in asset URL
+var swaggerUIAssets = map[string]SwaggerUIAsset{
+	"swagger-ui.css":       {ContentType: "text/css; charset=utf-8", Body: swaggerFiles.FileSwaggerUICSS},
+	"swagger-ui-bundle.js": {ContentType: "application/javascript; charset=utf-8", Body: swaggerFiles.FileSwaggerUIBundleJs},
+	"favicon-32x32.png":    {ContentType: "image/png", Body: swaggerFiles.FileFavicon32x32Png},
+	"favicon-16x16.png":    {ContentType: "image/png", Body: swaggerFiles.FileFavicon16x16Png},
+}
+
+const swaggerUIHTMLFormat = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>%[1]s</title>
+  <link rel="stylesheet" href="%[2]s/swagger-ui.css">
+  <link rel="icon" type="image/png" href="%[2]s/favicon-32x32.png" sizes="32x32">
+  <link rel="icon" type="image/png" href="%[2]s/favicon-16x16.png" sizes="16x16">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%[2]s/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "%[3]s", dom_id: "#swagger-ui", deepLinking: true });
+    };
+  </script>
+</body>
+</html>
+`
+
+// return html page of Swagger UI showing document served in specURL, assets are loaded from assetsURL (change in v.1.0.6)
+// ex) SwaggerUIHTML("API", "/openapi.json", "/swagger/assets")
+func SwaggerUIHTML(title, specURL, assetsURL string) string {
+	assetsURL = strings.TrimSuffix(assetsURL, "/")
+	return fmt.Sprintf(swaggerUIHTMLFormat, html.EscapeString(title), html.EscapeString(assetsURL), html.EscapeString(specURL))
+}
+
+// return asset of Swagger UI with file name, false is returned if asset is not loaded in page
+func SwaggerUIAssetOf(name string) (SwaggerUIAsset, bool) {
+	asset, ok := swaggerUIAssets[name]
+	return asset, ok
+}
@@ -0,0 +1,25 @@
+package openapi
+
+import (
+	"regexp"
+	"testing"
+)
+
+// check if all assets loaded in Swagger UI page are compiled into binary, so page is rendered without CDN (add in v.1.0.6)
+func TestSwaggerUIAssetsServed(t *testing.T) {
+	page := SwaggerUIHTML("API", "/openapi.json", "/swagger/assets/")
+	matches := regexp.MustCompile(`(?:href|src)="/swagger/assets/([^"]+)"`).FindAllStringSubmatch(page, -1)
+	if len(matches) == 0 {
+		t.Fatalf("no asset is loaded from assets URL in page, page: %s", page)
+	}
+
+	for _, match := range matches {
+		asset, ok := SwaggerUIAssetOf(match[1])
+		if !ok || len(asset.Body) == 0 || asset.ContentType == "" {
+			t.Errorf("asset loaded in page is not served, asset: %s", match[1])
+		}
+	}
+	if regexp.MustCompile(`(?:href|src)="https?://`).MatchString(page) {
+		t.Errorf("page must not load asset from external host, page: %s", page)
+	}
+}
@@ -0,0 +1,77 @@
+// add package in v.1.0.6
+// this package is used to project response into fields requested in fields query parameter (sparse fieldsets)
+// projection.go is file that declare tree of requested fields & function projecting value into fields of tree
+
+package projection
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Tree is requested fields, nested field separated with dot is child of field
+// ex) title,writer.name -> {title: {}, writer: {name: {}}}, field having no child is projected with all of its value
+type Tree map[string]Tree
+
+// return tree of fields separated with comma, empty field is ignored
+func Parse(fields string) Tree {
+	tree := Tree{}
+	for _, field := range strings.Split(fields, ",") {
+		node := tree
+		for _, key := range strings.Split(strings.TrimSpace(field), ".") {
+			if key == "" {
+				break
+			}
+			if _, ok := node[key]; !ok {
+				node[key] = Tree{}
+			}
+			node = node[key]
+		}
+	}
+	return tree
+}
+
+// return value projected into fields of tree, value isn't changed in place & projected into new map or slice
+// each item of slice is projected with same tree, fields not in value are ignored
+func (t Tree) Project(value interface{}) interface{} {
+	if len(t) == 0 || value == nil {
+		return value
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		projected := make(map[string]interface{}, len(t))
+		for key, child := range t {
+			if item := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())); item.IsValid() {
+				projected[key] = child.Project(item.Interface())
+			}
+		}
+		return projected
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return value // []byte is json string
+		}
+		projected := make([]interface{}, v.Len())
+		for i := range projected {
+			projected[i] = t.Project(v.Index(i).Interface())
+		}
+		return projected
+	case reflect.Struct, reflect.Ptr:
+		// struct (ex. entity, proto message) is projected after converted into map with json tag
+		b, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+		var generic interface{}
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return value
+		}
+		return t.Project(generic)
+	}
+	return value
+}
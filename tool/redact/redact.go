@@ -0,0 +1,184 @@
+// add package in v.1.0.6
+// this package is used to mask sensitive data (password, phone number, token, etc ...) before writing it in log or span
+// redact.go is file that declare function returning copy of value or header with sensitive data masked
+
+package redact
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Mask is value replacing sensitive data in log
+const Mask = "[REDACTED]"
+
+// field having this tag (`log:"redact"`) is always masked in Value function
+const (
+	tagKey   = "log"
+	tagValue = "redact"
+)
+
+// suffixes of normalized (lower case, without '_' & '-') field, key name regarded as sensitive data
+// this denylist is used for value not having log tag such as gRPC message or gin.H
+var sensitiveKeySuffixes = []string{"pw", "password", "phonenumber", "token", "authcode", "confirmcode", "secret"}
+
+// headers whose value must not be written in log
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Request-Security", "Cookie", "Set-Cookie"}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// return json-shaped copy of v (map[string]interface{}, []interface{}, etc ...) with sensitive field masked
+// field is masked if it has `log:"redact"` tag or its json name is in denylist
+func Value(v interface{}) interface{} {
+	return redactValue(reflect.ValueOf(v))
+}
+
+// return json encoding of value returned from Value function
+func Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(Value(v))
+}
+
+// return copy of header with value of sensitive header masked
+func Header(h http.Header) http.Header {
+	copied := h.Clone()
+	for _, key := range sensitiveHeaders {
+		if _, ok := copied[key]; ok {
+			copied[key] = []string{Mask}
+		}
+	}
+	return copied
+}
+
+// return true if name of field or key is in denylist of sensitive data
+func IsSensitiveKey(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// return name of exported fields in struct (or pointer to struct) v which look like sensitive data but don't have log tag
+// it is used to check that all request entities tag sensitive field with `log:"redact"`
+// fields of nested struct, pointer, slice, map type are checked too & returned with path (ex. Children.PhoneNumber) (change in v.1.0.6)
+func UntaggedSensitiveFields(v interface{}) (fields []string) {
+	return untaggedSensitiveFields(reflect.TypeOf(v), "", map[reflect.Type]bool{})
+}
+
+func untaggedSensitiveFields(t reflect.Type, prefix string, visited map[reflect.Type]bool) (fields []string) {
+	for t != nil && (t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Tag.Get(tagKey) == tagValue {
+			continue
+		}
+		if IsSensitiveKey(f.Name) || IsSensitiveKey(fieldName(f)) {
+			fields = append(fields, prefix+f.Name)
+			continue
+		}
+		fields = append(fields, untaggedSensitiveFields(f.Type, prefix+f.Name+".", visited)...)
+	}
+	return
+}
+
+func redactValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Implements(marshalerType) {
+			return v.Interface()
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		if v.Type().Implements(marshalerType) || reflect.PtrTo(v.Type()).Implements(marshalerType) {
+			return v.Interface()
+		}
+		return redactStruct(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		redacted := make(map[string]interface{}, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key := iter.Key().String()
+			if IsSensitiveKey(key) {
+				redacted[key] = Mask
+				continue
+			}
+			redacted[key] = redactValue(iter.Value())
+		}
+		return redacted
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		redacted := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			redacted[i] = redactValue(v.Index(i))
+		}
+		return redacted
+	default:
+		return v.Interface()
+	}
+}
+
+// redact struct with the same field name & omitempty rule as encoding/json
+func redactStruct(v reflect.Value) map[string]interface{} {
+	t := v.Type()
+	redacted := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := fieldName(f)
+		fv := v.Field(i)
+		if strings.Contains(tag, ",omitempty") && fv.IsZero() {
+			continue
+		}
+
+		if f.Tag.Get(tagKey) == tagValue || IsSensitiveKey(name) {
+			redacted[name] = Mask
+			continue
+		}
+		redacted[name] = redactValue(fv)
+	}
+	return redacted
+}
+
+// return name of field used in json encoding
+func fieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return f.Name
+}
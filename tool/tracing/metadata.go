@@ -0,0 +1,56 @@
+// add file in v.1.0.6
+// metadata.go is file that declare function propagating span context to gRPC service through go-micro metadata
+// and getting trace, span id from span context to correlate log with trace
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/opentracing/opentracing-go"
+	"net/http"
+	"strings"
+)
+
+// return context having span context in metadata as W3C trace context (traceparent, tracestate)
+// Span-Context of jaeger format is also set for services not yet reading W3C trace context
+func ContextWithSpanContext(ctx context.Context, tracer opentracing.Tracer, sc opentracing.SpanContext) context.Context {
+	header := http.Header{}
+	if err := tracer.Inject(sc, opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header)); err != nil {
+		return ctx
+	}
+
+	for key := range header {
+		ctx = metadata.Set(ctx, strings.ToLower(key), header.Get(key))
+	}
+	if spanContext, ok := jaegerSpanContextFrom(header.Get("traceparent")); ok {
+		ctx = metadata.Set(ctx, "Span-Context", spanContext)
+	}
+	return ctx
+}
+
+// convert traceparent (version-traceid-spanid-flags) to string of jaeger span context (traceid:spanid:parentid:flags)
+func jaegerSpanContextFrom(traceParent string) (spanContext string, ok bool) {
+	fields := strings.Split(traceParent, "-")
+	if len(fields) != 4 {
+		return
+	}
+	spanContext, ok = fmt.Sprintf("%s:%s:0:%s", fields[1], fields[2], fields[3]), true
+	return
+}
+
+// return trace id & span id of span context in hex string, empty string if unable to get it from tracer
+func IDsFromSpanContext(tracer opentracing.Tracer, sc opentracing.SpanContext) (traceID, spanID string) {
+	header := http.Header{}
+	if err := tracer.Inject(sc, opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header)); err != nil {
+		return
+	}
+
+	fields := strings.Split(header.Get("traceparent"), "-")
+	if len(fields) != 4 {
+		return
+	}
+	traceID, spanID = fields[1], fields[2]
+	return
+}
@@ -0,0 +1,73 @@
+// add package in v.1.0.6
+// this package is used to separate generating tracer of OpenTelemetry from main
+// new.go is file that declare function creating tracer exporting span with OTLP & bridged with opentracing
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/opentracing/opentracing-go"
+	otelbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"io"
+	"time"
+)
+
+// Config is the configuration used to create tracer in New function
+type Config struct {
+	ServiceName  string
+	OTLPEndpoint string // address of OTLP collector (ex. localhost:4317)
+	Sampler      string // one of sampler name declared in sampler.go (ex. parentbased_traceidratio)
+	SamplerArg   string // argument of sampler, fraction in ratio sampler, traces per second in rate limited sampler
+}
+
+// closer shut down tracer provider, flushing all remaining spans to exporter
+type closer func() error
+
+func (c closer) Close() error {
+	return c()
+}
+
+// create tracer exporting span to OTLP collector & return it bridged with opentracing.Tracer
+// so that handlers and middlewares keep using span of opentracing interface (TopSpan, etc ...)
+func New(cfg Config) (tracer opentracing.Tracer, c io.Closer, err error) {
+	sampler, err := NewSampler(cfg.Sampler, cfg.SamplerArg)
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to create sampler, err: %v", err))
+		return
+	}
+
+	exporter, err := otlp.NewExporter(context.Background(), otlpgrpc.NewDriver(
+		otlpgrpc.WithInsecure(),
+		otlpgrpc.WithEndpoint(cfg.OTLPEndpoint),
+	))
+	if err != nil {
+		err = errors.New(fmt.Sprintf("unable to create OTLP exporter, endpoint: %s, err: %v", cfg.OTLPEndpoint, err))
+		return
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sampler}),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(cfg.ServiceName))),
+		sdktrace.WithBatcher(exporter),
+	)
+
+	// extract & inject span context with W3C trace context (traceparent, tracestate) header
+	bridgeTracer, _ := otelbridge.NewTracerPair(provider.Tracer(cfg.ServiceName))
+	bridgeTracer.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	tracer = bridgeTracer
+	c = closer(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		return provider.Shutdown(ctx)
+	})
+	return
+}
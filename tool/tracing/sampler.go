@@ -0,0 +1,110 @@
+// add file in v.1.0.6
+// sampler.go is file that declare samplers deciding whether trace should be sampled & function getting sampler with name
+
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sampler names, same as value of OTEL_TRACES_SAMPLER in OpenTelemetry specification except rate limited samplers
+const (
+	AlwaysOnSampler                = "always_on"
+	AlwaysOffSampler               = "always_off"
+	TraceIDRatioSampler            = "traceidratio"
+	RateLimitedSampler             = "ratelimited"
+	ParentBasedAlwaysOnSampler     = "parentbased_always_on"
+	ParentBasedAlwaysOffSampler    = "parentbased_always_off"
+	ParentBasedTraceIDRatioSampler = "parentbased_traceidratio"
+	ParentBasedRateLimitedSampler  = "parentbased_ratelimited"
+)
+
+// return sampler with name & argument, parent based sampler follow sampling decision of parent span
+// if parent exists (ex. traceparent header sent from client) and use root sampler only for new trace
+func NewSampler(name, arg string) (sampler sdktrace.Sampler, err error) {
+	switch name {
+	case AlwaysOnSampler:
+		sampler = sdktrace.AlwaysSample()
+	case AlwaysOffSampler:
+		sampler = sdktrace.NeverSample()
+	case TraceIDRatioSampler:
+		sampler, err = newTraceIDRatioSampler(arg)
+	case RateLimitedSampler:
+		sampler, err = newRateLimitedSampler(arg)
+	case ParentBasedAlwaysOnSampler, "":
+		sampler = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case ParentBasedAlwaysOffSampler:
+		sampler = sdktrace.ParentBased(sdktrace.NeverSample())
+	case ParentBasedTraceIDRatioSampler:
+		if sampler, err = newTraceIDRatioSampler(arg); err == nil {
+			sampler = sdktrace.ParentBased(sampler)
+		}
+	case ParentBasedRateLimitedSampler:
+		if sampler, err = newRateLimitedSampler(arg); err == nil {
+			sampler = sdktrace.ParentBased(sampler)
+		}
+	default:
+		err = errors.New(fmt.Sprintf("%s is an unsupported sampler", name))
+	}
+	return
+}
+
+func newTraceIDRatioSampler(arg string) (sdktrace.Sampler, error) {
+	fraction, err := strconv.ParseFloat(arg, 64)
+	if err != nil || fraction < 0 || fraction > 1 {
+		return nil, errors.New(fmt.Sprintf("argument of ratio sampler must be float between 0 and 1, arg: %s", arg))
+	}
+	return sdktrace.TraceIDRatioBased(fraction), nil
+}
+
+func newRateLimitedSampler(arg string) (sdktrace.Sampler, error) {
+	maxPerSecond, err := strconv.ParseFloat(arg, 64)
+	if err != nil || maxPerSecond <= 0 {
+		return nil, errors.New(fmt.Sprintf("argument of rate limited sampler must be positive float, arg: %s", arg))
+	}
+	return &rateLimitedSampler{
+		maxPerSecond: maxPerSecond,
+		maxBalance:   math.Max(maxPerSecond, 1),
+		balance:      math.Max(maxPerSecond, 1),
+		lastTick:     time.Now(),
+		mutex:        sync.Mutex{},
+	}, nil
+}
+
+// rateLimitedSampler samples at most maxPerSecond traces per second using token bucket algorithm
+type rateLimitedSampler struct {
+	maxPerSecond float64
+	maxBalance   float64 // at least 1 so that sampler with max per second under 1 can sample
+	balance      float64
+	lastTick     time.Time
+	mutex        sync.Mutex
+}
+
+func (s *rateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// refill balance as much as time elapsed from last tick, not exceeding max balance
+	now := time.Now()
+	s.balance += now.Sub(s.lastTick).Seconds() * s.maxPerSecond
+	if s.balance > s.maxBalance {
+		s.balance = s.maxBalance
+	}
+	s.lastTick = now
+
+	if s.balance < 1 {
+		return sdktrace.SamplingResult{Decision: sdktrace.Drop, Tracestate: p.ParentContext.TraceState}
+	}
+	s.balance -= 1
+	return sdktrace.SamplingResult{Decision: sdktrace.RecordAndSample, Tracestate: p.ParentContext.TraceState}
+}
+
+func (s *rateLimitedSampler) Description() string {
+	return fmt.Sprintf("RateLimited{%g}", s.maxPerSecond)
+}